@@ -0,0 +1,63 @@
+package v1alpha1
+
+import (
+	"errors"
+	"net/netip"
+
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+// An AddressPool allocates the addresses from its start to its end address.
+// Its hooks check the range as a whole, which the rules of its fields cannot.
+// +generate:validate
+// +validate(before=checkFamily)
+type AddressPool struct {
+	// Start is the first address of the pool
+	// +validate(length(min = 1))
+	Start string `json:"start"`
+	// End is the last address of the pool
+	// +validate(length(min = 1))
+	End string `json:"end"`
+}
+
+// ValidateBefore reports the addresses of the pool that are set but cannot
+// be parsed.
+func (r *AddressPool) ValidateBefore() error {
+	var errs error
+	if _, err := netip.ParseAddr(r.Start); r.Start != "" && err != nil {
+		errs = errors.Join(errs, &validation.Error{Field: "start", Err: err})
+	}
+	if _, err := netip.ParseAddr(r.End); r.End != "" && err != nil {
+		errs = errors.Join(errs, &validation.Error{Field: "end", Err: err})
+	}
+	return errs
+}
+
+// checkFamily reports a pool whose addresses are of different families.
+func (r *AddressPool) checkFamily() error {
+	start, end, ok := r.addrs()
+	if !ok || start.Is4() == end.Is4() {
+		return nil
+	}
+	return validation.Invalid("end", validation.ErrRange, "range.family", "must be of the family of {start}", validation.Args{"start": r.Start})
+}
+
+// ValidateAfter reports a pool whose end address precedes its start address.
+func (r *AddressPool) ValidateAfter() error {
+	start, end, ok := r.addrs()
+	if !ok || start.Is4() != end.Is4() || !end.Less(start) {
+		return nil
+	}
+	return validation.Invalid("end", validation.ErrRange, "range.order", "must not precede {start}", validation.Args{"start": r.Start})
+}
+
+// addrs returns the parsed addresses of the pool, or false if one cannot be
+// parsed.
+func (r *AddressPool) addrs() (start, end netip.Addr, ok bool) {
+	start, err := netip.ParseAddr(r.Start)
+	if err != nil {
+		return start, end, false
+	}
+	end, err = netip.ParseAddr(r.End)
+	return start, end, err == nil
+}
@@ -0,0 +1,146 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+func (r *AddressPool) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *AddressPool) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *AddressPool) ValidateContext(ctx context.Context, opts validation.Options) error {
+	var errs error
+	errs = errors.Join(errs, r.ValidateBefore())
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	errs = errors.Join(errs, r.checkFamily())
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if len(r.Start) < 1 {
+		errs = errors.Join(errs, validation.Invalid("start", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(r.Start)}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if len(r.End) < 1 {
+		errs = errors.Join(errs, validation.Invalid("end", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(r.End)}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	errs = errors.Join(errs, r.ValidateAfter())
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *AddressPool) ValidateUpdate(old *AddressPool) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *AddressPool) ValidateUpdateContext(ctx context.Context, old *AddressPool, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	errs = errors.Join(errs, r.ValidateBefore())
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	errs = errors.Join(errs, r.checkFamily())
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if len(r.Start) < 1 {
+		errs = errors.Join(errs, validation.Invalid("start", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(r.Start)}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if len(r.End) < 1 {
+		errs = errors.Join(errs, validation.Invalid("end", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(r.End)}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	errs = errors.Join(errs, r.ValidateAfter())
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *AddressPool) ValidateRatcheting(old *AddressPool) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *AddressPool) ValidateRatchetingContext(ctx context.Context, old *AddressPool, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	errs = errors.Join(errs, r.ValidateBefore())
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	errs = errors.Join(errs, r.checkFamily())
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if r.Start != old.Start {
+		if len(r.Start) < 1 {
+			errs = errors.Join(errs, validation.Invalid("start", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(r.Start)}))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if r.End != old.End {
+		if len(r.End) < 1 {
+			errs = errors.Join(errs, validation.Invalid("end", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(r.End)}))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	errs = errors.Join(errs, r.ValidateAfter())
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,4 @@
+// Package v1alpha1 holds API types using the generator features the other
+// APIs of the tree do not use, so their generated code is compiled with the
+// tree.
+package v1alpha1
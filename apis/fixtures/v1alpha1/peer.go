@@ -0,0 +1,23 @@
+package v1alpha1
+
+// A Peer is a BGP peer given by its address or discovered on an interface.
+// The rules across its fields are kept with the type.
+// +generate:validate
+// +validate(required_without(fields=[interface]), target=address)
+// +validate(required_if(field=authentication, value=md5), target=password)
+// +validate(required_with(fields=[password]), target=authentication)
+// +validate(excluded_with(fields=[passive]), target=remotePort)
+type Peer struct {
+	// Address is the address of the peer.
+	Address *string `json:"address,omitempty"`
+	// Interface is the interface the peer is discovered on.
+	Interface *string `json:"interface,omitempty"`
+	// Authentication is the authentication of the session, e.g. md5.
+	Authentication string `json:"authentication,omitempty"`
+	// Password is the password of the authentication.
+	Password string `json:"password,omitempty"`
+	// Passive sessions wait for the peer to connect.
+	Passive bool `json:"passive,omitempty"`
+	// RemotePort is the port of the peer the session connects to.
+	RemotePort *int32 `json:"remotePort,omitempty"`
+}
@@ -0,0 +1,151 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/henderiw/godantic/pkg/diff"
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+func (r *Peer) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *Peer) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *Peer) ValidateContext(ctx context.Context, opts validation.Options) error {
+	var errs error
+	if !validation.IsSet(r.Interface) && !validation.IsSet(r.Address) {
+		errs = errors.Join(errs, validation.Invalid("address", validation.ErrRequired, "required.without", "field is required when {fields} is not set", validation.Args{"fields": "interface"}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if validation.IsSet(r.Password) && !validation.IsSet(r.Authentication) {
+		errs = errors.Join(errs, validation.Invalid("authentication", validation.ErrRequired, "required.with", "field is required when {fields} is set", validation.Args{"fields": "password"}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if validation.Equals(r.Authentication, "md5") && !validation.IsSet(r.Password) {
+		errs = errors.Join(errs, validation.Invalid("password", validation.ErrRequired, "required.if", "field is required when {if_field} is {value}", validation.Args{"if_field": "authentication", "value": "md5"}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if validation.IsSet(r.Passive) && validation.IsSet(r.RemotePort) {
+		errs = errors.Join(errs, validation.Invalid("remotePort", validation.ErrExcluded, "excluded.with", "field must not be set when {fields} is set", validation.Args{"fields": "passive"}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *Peer) ValidateUpdate(old *Peer) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *Peer) ValidateUpdateContext(ctx context.Context, old *Peer, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !validation.IsSet(r.Interface) && !validation.IsSet(r.Address) {
+		errs = errors.Join(errs, validation.Invalid("address", validation.ErrRequired, "required.without", "field is required when {fields} is not set", validation.Args{"fields": "interface"}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if validation.IsSet(r.Password) && !validation.IsSet(r.Authentication) {
+		errs = errors.Join(errs, validation.Invalid("authentication", validation.ErrRequired, "required.with", "field is required when {fields} is set", validation.Args{"fields": "password"}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if validation.Equals(r.Authentication, "md5") && !validation.IsSet(r.Password) {
+		errs = errors.Join(errs, validation.Invalid("password", validation.ErrRequired, "required.if", "field is required when {if_field} is {value}", validation.Args{"if_field": "authentication", "value": "md5"}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if validation.IsSet(r.Passive) && validation.IsSet(r.RemotePort) {
+		errs = errors.Join(errs, validation.Invalid("remotePort", validation.ErrExcluded, "excluded.with", "field must not be set when {fields} is set", validation.Args{"fields": "passive"}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *Peer) ValidateRatcheting(old *Peer) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *Peer) ValidateRatchetingContext(ctx context.Context, old *Peer, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !diff.PointerEqual(r.Address, old.Address) || !diff.PointerEqual(r.Interface, old.Interface) {
+		if !validation.IsSet(r.Interface) && !validation.IsSet(r.Address) {
+			errs = errors.Join(errs, validation.Invalid("address", validation.ErrRequired, "required.without", "field is required when {fields} is not set", validation.Args{"fields": "interface"}))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if r.Authentication != old.Authentication || r.Password != old.Password {
+		if validation.IsSet(r.Password) && !validation.IsSet(r.Authentication) {
+			errs = errors.Join(errs, validation.Invalid("authentication", validation.ErrRequired, "required.with", "field is required when {fields} is set", validation.Args{"fields": "password"}))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if r.Password != old.Password || r.Authentication != old.Authentication {
+		if validation.Equals(r.Authentication, "md5") && !validation.IsSet(r.Password) {
+			errs = errors.Join(errs, validation.Invalid("password", validation.ErrRequired, "required.if", "field is required when {if_field} is {value}", validation.Args{"if_field": "authentication", "value": "md5"}))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !diff.PointerEqual(r.RemotePort, old.RemotePort) || r.Passive != old.Passive {
+		if validation.IsSet(r.Passive) && validation.IsSet(r.RemotePort) {
+			errs = errors.Join(errs, validation.Invalid("remotePort", validation.ErrExcluded, "excluded.with", "field must not be set when {fields} is set", validation.Args{"fields": "passive"}))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package v1alpha1
+
+// A Prefix is an IP prefix divided into smaller prefixes, which makes the
+// type recursive.
+// +generate:validate
+type Prefix struct {
+	// Prefix is the IP prefix in CIDR notation
+	// +validate(length(min = 1))
+	Prefix string `json:"prefix"`
+	// Children are the prefixes the prefix is divided into
+	Children []*Prefix `json:"children,omitempty"`
+}
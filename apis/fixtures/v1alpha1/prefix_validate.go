@@ -0,0 +1,156 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+func (r *Prefix) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *Prefix) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *Prefix) ValidateContext(ctx context.Context, opts validation.Options) error {
+	// the type is recursive
+	opts, err := opts.Enter(r)
+	if err != nil {
+		return err
+	}
+	var errs error
+	if len(r.Prefix) < 1 {
+		errs = errors.Join(errs, validation.Invalid("prefix", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(r.Prefix)}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	for i, item := range r.Children {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(errs, err)
+		}
+		if item != nil {
+			if err := item.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+				errs = errors.Join(errs, validation.Prefix(validation.Index("children", i), err))
+			}
+		}
+		if opts.Exceeded(errs) {
+			return errs
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *Prefix) ValidateUpdate(old *Prefix) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *Prefix) ValidateUpdateContext(ctx context.Context, old *Prefix, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	// the type is recursive
+	opts, err := opts.Enter(r)
+	if err != nil {
+		return err
+	}
+	var errs error
+	if len(r.Prefix) < 1 {
+		errs = errors.Join(errs, validation.Invalid("prefix", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(r.Prefix)}))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	for i, item := range r.Children {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(errs, err)
+		}
+		oldItem := validation.PointerAt(old.Children, i)
+		if item != nil {
+			if err := item.ValidateUpdateContext(ctx, oldItem, opts.Nested(errs)); err != nil {
+				errs = errors.Join(errs, validation.Prefix(validation.Index("children", i), err))
+			}
+		}
+		if opts.Exceeded(errs) {
+			return errs
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *Prefix) ValidateRatcheting(old *Prefix) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *Prefix) ValidateRatchetingContext(ctx context.Context, old *Prefix, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	// the type is recursive
+	opts, err := opts.Enter(r)
+	if err != nil {
+		return err
+	}
+	var errs error
+	if r.Prefix != old.Prefix {
+		if len(r.Prefix) < 1 {
+			errs = errors.Join(errs, validation.Invalid("prefix", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(r.Prefix)}))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.Children, old.Children) {
+		for i, item := range r.Children {
+			if err := ctx.Err(); err != nil {
+				return errors.Join(errs, err)
+			}
+			if item != nil {
+				if err := item.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+					errs = errors.Join(errs, validation.Prefix(validation.Index("children", i), err))
+				}
+			}
+			if opts.Exceeded(errs) {
+				return errs
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
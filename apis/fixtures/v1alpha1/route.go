@@ -0,0 +1,24 @@
+package v1alpha1
+
+// +generate:validate
+type NextHopType string
+
+const (
+	NextHopTypeInterface NextHopType = "interface"
+	NextHopTypeGateway   NextHopType = "gateway"
+)
+
+// A Route reaches its destination through a single next hop, which is
+// selected by its type.
+// +generate:validate
+// +union(discriminator=type, members=[interface,gateway], mode=exactly_one)
+type Route struct {
+	// Destination is the prefix reached through the route
+	Destination string `json:"destination"`
+	// Type selects the next hop of the route
+	Type NextHopType `json:"type,omitempty"`
+	// Interface is the name of the interface the destination is connected to
+	Interface *string `json:"interface,omitempty"`
+	// Gateway is the address of the router the destination is reached through
+	Gateway *string `json:"gateway,omitempty"`
+}
@@ -0,0 +1,19 @@
+package v1alpha1
+
+// A RouteTable holds the routes of a VRF with their communities and labels.
+// +generate:validate
+type RouteTable struct {
+	// Communities are the BGP communities of the routes, e.g. 65000:100
+	// +validate(each(regex(pattern = "^[0-9]+:[0-9]+$")))
+	Communities []string `json:"communities,omitempty"`
+	// Labels are the labels of the table
+	// +validate(keys(length(min = 1, max = 63)))
+	// +validate(values(length(max = 63)))
+	Labels map[string]string `json:"labels,omitempty"`
+	// NextHopGroups are the groups of next hop addresses routes resolve to
+	// +validate(each(each(length(min = 1))))
+	NextHopGroups [][]string `json:"nextHopGroups,omitempty"`
+	// Preferences are the administrative distances of the routing protocols
+	// +validate(values(range(min = 1, max = 255)))
+	Preferences map[string]*int32 `json:"preferences,omitempty"`
+}
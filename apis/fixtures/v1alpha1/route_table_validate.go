@@ -0,0 +1,225 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+func (r *RouteTable) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *RouteTable) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *RouteTable) ValidateContext(ctx context.Context, opts validation.Options) error {
+	var errs error
+	for i, item := range r.Communities {
+		if !validation.MatchString("^[0-9]+:[0-9]+$", string(item)) {
+			errs = errors.Join(errs, validation.Invalid(validation.Index("communities", i), validation.ErrRegex, "regex.match", "must match {pattern}, got {value}", validation.Args{"pattern": "^[0-9]+:[0-9]+$", "value": string(item)}))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	for _, k := range validation.SortedKeys(r.Labels) {
+		if len(k) < 1 {
+			errs = errors.Join(errs, validation.Invalid(validation.Key("labels", k), validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(k)}))
+		}
+		if len(k) > 63 {
+			errs = errors.Join(errs, validation.Invalid(validation.Key("labels", k), validation.ErrLength, "length.max", "length must be at most {max}, got {len}", validation.Args{"max": 63, "len": len(k)}))
+		}
+	}
+	for _, k := range validation.SortedKeys(r.Labels) {
+		value := r.Labels[k]
+		if len(value) > 63 {
+			errs = errors.Join(errs, validation.Invalid(validation.Key("labels", k), validation.ErrLength, "length.max", "length must be at most {max}, got {len}", validation.Args{"max": 63, "len": len(value)}))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	for i, item := range r.NextHopGroups {
+		for i1, item1 := range item {
+			if len(item1) < 1 {
+				errs = errors.Join(errs, validation.Invalid(validation.Index(validation.Index("nextHopGroups", i), i1), validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(item1)}))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	for _, k := range validation.SortedKeys(r.Preferences) {
+		value := r.Preferences[k]
+		if value != nil {
+			if *value < 1.000000 {
+				errs = errors.Join(errs, validation.Invalid(validation.Key("preferences", k), validation.ErrRange, "range.min", "must be greater than or equal to {min}, got {value}", validation.Args{"min": 1, "value": *value}))
+			}
+			if *value > 255.000000 {
+				errs = errors.Join(errs, validation.Invalid(validation.Key("preferences", k), validation.ErrRange, "range.max", "must be less than or equal to {max}, got {value}", validation.Args{"max": 255, "value": *value}))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *RouteTable) ValidateUpdate(old *RouteTable) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *RouteTable) ValidateUpdateContext(ctx context.Context, old *RouteTable, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	for i, item := range r.Communities {
+		if !validation.MatchString("^[0-9]+:[0-9]+$", string(item)) {
+			errs = errors.Join(errs, validation.Invalid(validation.Index("communities", i), validation.ErrRegex, "regex.match", "must match {pattern}, got {value}", validation.Args{"pattern": "^[0-9]+:[0-9]+$", "value": string(item)}))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	for _, k := range validation.SortedKeys(r.Labels) {
+		if len(k) < 1 {
+			errs = errors.Join(errs, validation.Invalid(validation.Key("labels", k), validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(k)}))
+		}
+		if len(k) > 63 {
+			errs = errors.Join(errs, validation.Invalid(validation.Key("labels", k), validation.ErrLength, "length.max", "length must be at most {max}, got {len}", validation.Args{"max": 63, "len": len(k)}))
+		}
+	}
+	for _, k := range validation.SortedKeys(r.Labels) {
+		value := r.Labels[k]
+		if len(value) > 63 {
+			errs = errors.Join(errs, validation.Invalid(validation.Key("labels", k), validation.ErrLength, "length.max", "length must be at most {max}, got {len}", validation.Args{"max": 63, "len": len(value)}))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	for i, item := range r.NextHopGroups {
+		for i1, item1 := range item {
+			if len(item1) < 1 {
+				errs = errors.Join(errs, validation.Invalid(validation.Index(validation.Index("nextHopGroups", i), i1), validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(item1)}))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	for _, k := range validation.SortedKeys(r.Preferences) {
+		value := r.Preferences[k]
+		if value != nil {
+			if *value < 1.000000 {
+				errs = errors.Join(errs, validation.Invalid(validation.Key("preferences", k), validation.ErrRange, "range.min", "must be greater than or equal to {min}, got {value}", validation.Args{"min": 1, "value": *value}))
+			}
+			if *value > 255.000000 {
+				errs = errors.Join(errs, validation.Invalid(validation.Key("preferences", k), validation.ErrRange, "range.max", "must be less than or equal to {max}, got {value}", validation.Args{"max": 255, "value": *value}))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *RouteTable) ValidateRatcheting(old *RouteTable) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *RouteTable) ValidateRatchetingContext(ctx context.Context, old *RouteTable, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !validation.DeepEqual(r.Communities, old.Communities) {
+		for i, item := range r.Communities {
+			if !validation.MatchString("^[0-9]+:[0-9]+$", string(item)) {
+				errs = errors.Join(errs, validation.Invalid(validation.Index("communities", i), validation.ErrRegex, "regex.match", "must match {pattern}, got {value}", validation.Args{"pattern": "^[0-9]+:[0-9]+$", "value": string(item)}))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.Labels, old.Labels) {
+		for _, k := range validation.SortedKeys(r.Labels) {
+			if len(k) < 1 {
+				errs = errors.Join(errs, validation.Invalid(validation.Key("labels", k), validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(k)}))
+			}
+			if len(k) > 63 {
+				errs = errors.Join(errs, validation.Invalid(validation.Key("labels", k), validation.ErrLength, "length.max", "length must be at most {max}, got {len}", validation.Args{"max": 63, "len": len(k)}))
+			}
+		}
+		for _, k := range validation.SortedKeys(r.Labels) {
+			value := r.Labels[k]
+			if len(value) > 63 {
+				errs = errors.Join(errs, validation.Invalid(validation.Key("labels", k), validation.ErrLength, "length.max", "length must be at most {max}, got {len}", validation.Args{"max": 63, "len": len(value)}))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.NextHopGroups, old.NextHopGroups) {
+		for i, item := range r.NextHopGroups {
+			for i1, item1 := range item {
+				if len(item1) < 1 {
+					errs = errors.Join(errs, validation.Invalid(validation.Index(validation.Index("nextHopGroups", i), i1), validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(item1)}))
+				}
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.Preferences, old.Preferences) {
+		for _, k := range validation.SortedKeys(r.Preferences) {
+			value := r.Preferences[k]
+			if value != nil {
+				if *value < 1.000000 {
+					errs = errors.Join(errs, validation.Invalid(validation.Key("preferences", k), validation.ErrRange, "range.min", "must be greater than or equal to {min}, got {value}", validation.Args{"min": 1, "value": *value}))
+				}
+				if *value > 255.000000 {
+					errs = errors.Join(errs, validation.Invalid(validation.Key("preferences", k), validation.ErrRange, "range.max", "must be less than or equal to {max}, got {value}", validation.Args{"max": 255, "value": *value}))
+				}
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
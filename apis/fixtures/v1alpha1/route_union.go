@@ -0,0 +1,24 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import (
+	"encoding/json"
+)
+
+// UnmarshalJSON decodes the object and keeps only the members of its unions
+// named by their discriminator.
+func (r *Route) UnmarshalJSON(data []byte) error {
+	// plain has the fields but not the methods of the type
+	type plain Route
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	var zero plain
+	switch string(r.Type) {
+	case "interface":
+		r.Gateway = zero.Gateway
+	case "gateway":
+		r.Interface = zero.Interface
+	}
+	return nil
+}
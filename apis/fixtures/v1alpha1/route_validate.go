@@ -0,0 +1,127 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/henderiw/godantic/pkg/diff"
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+func (r NextHopType) Validate() error {
+	valid := map[string]struct{}{"interface": {}, "gateway": {}}
+	if _, ok := valid[string(r)]; !ok {
+		return validation.Invalid("", validation.ErrEnum, "enum", "NextHopType must be one of \"interface\", \"gateway\", got \"{value}\"", validation.Args{"value": r})
+	}
+	return nil
+}
+func (r *Route) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *Route) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *Route) ValidateContext(ctx context.Context, opts validation.Options) error {
+	var errs error
+	if err := r.Type.Validate(); err != nil {
+		errs = errors.Join(errs, validation.Prefix("type", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	// exactly one of interface, gateway is set
+	errs = errors.Join(errs, validation.Union{
+		Members:       []string{"interface", "gateway"},
+		ExactlyOne:    true,
+		Discriminator: "type",
+	}.Check([]bool{validation.IsSet(r.Interface), validation.IsSet(r.Gateway)}, string(r.Type)))
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *Route) ValidateUpdate(old *Route) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *Route) ValidateUpdateContext(ctx context.Context, old *Route, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if err := r.Type.Validate(); err != nil {
+		errs = errors.Join(errs, validation.Prefix("type", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	// exactly one of interface, gateway is set
+	errs = errors.Join(errs, validation.Union{
+		Members:       []string{"interface", "gateway"},
+		ExactlyOne:    true,
+		Discriminator: "type",
+	}.Check([]bool{validation.IsSet(r.Interface), validation.IsSet(r.Gateway)}, string(r.Type)))
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *Route) ValidateRatcheting(old *Route) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *Route) ValidateRatchetingContext(ctx context.Context, old *Route, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if r.Type != old.Type {
+		if err := r.Type.Validate(); err != nil {
+			errs = errors.Join(errs, validation.Prefix("type", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !diff.PointerEqual(r.Interface, old.Interface) || !diff.PointerEqual(r.Gateway, old.Gateway) || r.Type != old.Type {
+		// exactly one of interface, gateway is set
+		errs = errors.Join(errs, validation.Union{
+			Members:       []string{"interface", "gateway"},
+			ExactlyOne:    true,
+			Discriminator: "type",
+		}.Check([]bool{validation.IsSet(r.Interface), validation.IsSet(r.Gateway)}, string(r.Type)))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import ()
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *BFDLinkParameters) DeepCopyInto(out *BFDLinkParameters) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinTx != nil {
+		in, out := &in.MinTx, &out.MinTx
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MinRx != nil {
+		in, out := &in.MinRx, &out.MinRx
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MinEchoRx != nil {
+		in, out := &in.MinEchoRx, &out.MinEchoRx
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Multiplier != nil {
+		in, out := &in.Multiplier, &out.Multiplier
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new BFDLinkParameters by deep copying the receiver.
+func (in *BFDLinkParameters) DeepCopy() *BFDLinkParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(BFDLinkParameters)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,24 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import ()
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *BGPLinkParameters) DeepCopyInto(out *BGPLinkParameters) {
+	*out = *in
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new BGPLinkParameters by deep copying the receiver.
+func (in *BGPLinkParameters) DeepCopy() *BGPLinkParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPLinkParameters)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,8 +1,57 @@
 // GENERATED CODE - DO NOT EDIT
 package v1alpha1
 
-import ()
+import (
+	"context"
+
+	"github.com/henderiw/godantic/pkg/validation"
+)
 
 func (r *BGPLinkParameters) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *BGPLinkParameters) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *BGPLinkParameters) ValidateContext(ctx context.Context, opts validation.Options) error {
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *BGPLinkParameters) ValidateUpdate(old *BGPLinkParameters) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *BGPLinkParameters) ValidateUpdateContext(ctx context.Context, old *BGPLinkParameters, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *BGPLinkParameters) ValidateRatcheting(old *BGPLinkParameters) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *BGPLinkParameters) ValidateRatchetingContext(ctx context.Context, old *BGPLinkParameters, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
 	return nil
 }
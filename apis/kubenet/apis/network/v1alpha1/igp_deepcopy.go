@@ -0,0 +1,39 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import ()
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *IGPLinkParameters) DeepCopyInto(out *IGPLinkParameters) {
+	*out = *in
+	if in.NetworkType != nil {
+		in, out := &in.NetworkType, &out.NetworkType
+		*out = new(NetworkType)
+		**out = **in
+	}
+	if in.Passive != nil {
+		in, out := &in.Passive, &out.Passive
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new IGPLinkParameters by deep copying the receiver.
+func (in *IGPLinkParameters) DeepCopy() *IGPLinkParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(IGPLinkParameters)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,8 +1,97 @@
 // GENERATED CODE - DO NOT EDIT
 package v1alpha1
 
-import ()
+import (
+	"context"
+	"errors"
+
+	"github.com/henderiw/godantic/pkg/diff"
+	"github.com/henderiw/godantic/pkg/validation"
+)
 
 func (r *IGPLinkParameters) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *IGPLinkParameters) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *IGPLinkParameters) ValidateContext(ctx context.Context, opts validation.Options) error {
+	var errs error
+	if r.NetworkType != nil {
+		if err := r.NetworkType.Validate(); err != nil {
+			errs = errors.Join(errs, validation.Prefix("networkType", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *IGPLinkParameters) ValidateUpdate(old *IGPLinkParameters) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *IGPLinkParameters) ValidateUpdateContext(ctx context.Context, old *IGPLinkParameters, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if r.NetworkType != nil {
+		if err := r.NetworkType.Validate(); err != nil {
+			errs = errors.Join(errs, validation.Prefix("networkType", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *IGPLinkParameters) ValidateRatcheting(old *IGPLinkParameters) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *IGPLinkParameters) ValidateRatchetingContext(ctx context.Context, old *IGPLinkParameters, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !diff.PointerEqual(r.NetworkType, old.NetworkType) {
+		if r.NetworkType != nil {
+			if err := r.NetworkType.Validate(); err != nil {
+				errs = errors.Join(errs, validation.Prefix("networkType", err))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
 	return nil
 }
@@ -0,0 +1,25 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import ()
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *ISISLinkParameters) DeepCopyInto(out *ISISLinkParameters) {
+	*out = *in
+	in.IGPLinkParameters.DeepCopyInto(&out.IGPLinkParameters)
+	if in.Level != nil {
+		in, out := &in.Level, &out.Level
+		*out = new(ISISLevel)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new ISISLinkParameters by deep copying the receiver.
+func (in *ISISLinkParameters) DeepCopy() *ISISLinkParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ISISLinkParameters)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -2,24 +2,121 @@
 package v1alpha1
 
 import (
+	"context"
 	"errors"
-	"fmt"
+
+	"github.com/henderiw/godantic/pkg/diff"
+	"github.com/henderiw/godantic/pkg/validation"
 )
 
 func (r ISISLevel) Validate() error {
 	valid := map[string]struct{}{"L1": {}, "L2": {}, "L1L2": {}}
 	if _, ok := valid[string(r)]; !ok {
-		return fmt.Errorf("invalid value for ISISLevel: %s", r)
+		return validation.Invalid("", validation.ErrEnum, "enum", "ISISLevel must be one of \"L1\", \"L2\", \"L1L2\", got \"{value}\"", validation.Args{"value": r})
 	}
 	return nil
 }
 func (r *ISISLinkParameters) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *ISISLinkParameters) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *ISISLinkParameters) ValidateContext(ctx context.Context, opts validation.Options) error {
 	var errs error
+	if err := r.IGPLinkParameters.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
 	if r.Level != nil {
 		if err := r.Level.Validate(); err != nil {
+			errs = errors.Join(errs, validation.Prefix("area", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *ISISLinkParameters) ValidateUpdate(old *ISISLinkParameters) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *ISISLinkParameters) ValidateUpdateContext(ctx context.Context, old *ISISLinkParameters, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if err := r.IGPLinkParameters.ValidateUpdateContext(ctx, &old.IGPLinkParameters, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if r.Level != nil {
+		if err := r.Level.Validate(); err != nil {
+			errs = errors.Join(errs, validation.Prefix("area", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *ISISLinkParameters) ValidateRatcheting(old *ISISLinkParameters) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *ISISLinkParameters) ValidateRatchetingContext(ctx context.Context, old *ISISLinkParameters, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !validation.DeepEqual(r.IGPLinkParameters, old.IGPLinkParameters) {
+		if err := r.IGPLinkParameters.ValidateRatchetingContext(ctx, &old.IGPLinkParameters, opts.Nested(errs)); err != nil {
 			errs = errors.Join(errs, err)
 		}
 	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !diff.PointerEqual(r.Level, old.Level) {
+		if r.Level != nil {
+			if err := r.Level.Validate(); err != nil {
+				errs = errors.Join(errs, validation.Prefix("area", err))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
 	if errs != nil {
 		return errs
 	}
@@ -2,20 +2,20 @@
 package v1alpha1
 
 import (
-	"fmt"
+	"github.com/henderiw/godantic/pkg/validation"
 )
 
 func (r NetworkType) Validate() error {
 	valid := map[string]struct{}{"pointToPoint": {}, "broadcast": {}}
 	if _, ok := valid[string(r)]; !ok {
-		return fmt.Errorf("invalid value for NetworkType: %s", r)
+		return validation.Invalid("", validation.ErrEnum, "enum", "NetworkType must be one of \"pointToPoint\", \"broadcast\", got \"{value}\"", validation.Args{"value": r})
 	}
 	return nil
 }
 func (r Dummy) Validate() error {
 	valid := map[int64]struct{}{0: {}, 1: {}, 2: {}}
 	if _, ok := valid[int64(r)]; !ok {
-		return fmt.Errorf("invalid value for Dummy: %d", r)
+		return validation.Invalid("", validation.ErrEnum, "enum", "Dummy must be one of 0, 1, 2, got \"{value}\"", validation.Args{"value": r})
 	}
 	return nil
 }
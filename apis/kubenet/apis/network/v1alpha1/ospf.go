@@ -13,5 +13,6 @@ type OSPFLinkParameters struct {
 	// Generic IGP Link Parameters
 	IGPLinkParameters `json:",inline"`
 	// Defines the OSPF area the link is assocaited with
+	// +validate(required)
 	Area *string `json:"area,omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,25 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import ()
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *OSPFLinkParameters) DeepCopyInto(out *OSPFLinkParameters) {
+	*out = *in
+	in.IGPLinkParameters.DeepCopyInto(&out.IGPLinkParameters)
+	if in.Area != nil {
+		in, out := &in.Area, &out.Area
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new OSPFLinkParameters by deep copying the receiver.
+func (in *OSPFLinkParameters) DeepCopy() *OSPFLinkParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OSPFLinkParameters)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -2,16 +2,117 @@
 package v1alpha1
 
 import (
-	"fmt"
+	"context"
+	"errors"
+
+	"github.com/henderiw/godantic/pkg/diff"
+	"github.com/henderiw/godantic/pkg/validation"
 )
 
 func (r OSPFVersion) Validate() error {
 	valid := map[string]struct{}{"v2": {}, "v3": {}}
 	if _, ok := valid[string(r)]; !ok {
-		return fmt.Errorf("invalid value for OSPFVersion: %s", r)
+		return validation.Invalid("", validation.ErrEnum, "enum", "OSPFVersion must be one of \"v2\", \"v3\", got \"{value}\"", validation.Args{"value": r})
 	}
 	return nil
 }
 func (r *OSPFLinkParameters) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *OSPFLinkParameters) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *OSPFLinkParameters) ValidateContext(ctx context.Context, opts validation.Options) error {
+	var errs error
+	if err := r.IGPLinkParameters.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.IsSet(r.Area) {
+		errs = errors.Join(errs, validation.Invalid("area", validation.ErrRequired, "required", "field is required", nil))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *OSPFLinkParameters) ValidateUpdate(old *OSPFLinkParameters) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *OSPFLinkParameters) ValidateUpdateContext(ctx context.Context, old *OSPFLinkParameters, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if err := r.IGPLinkParameters.ValidateUpdateContext(ctx, &old.IGPLinkParameters, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.IsSet(r.Area) {
+		errs = errors.Join(errs, validation.Invalid("area", validation.ErrRequired, "required", "field is required", nil))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *OSPFLinkParameters) ValidateRatcheting(old *OSPFLinkParameters) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *OSPFLinkParameters) ValidateRatchetingContext(ctx context.Context, old *OSPFLinkParameters, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !validation.DeepEqual(r.IGPLinkParameters, old.IGPLinkParameters) {
+		if err := r.IGPLinkParameters.ValidateRatchetingContext(ctx, &old.IGPLinkParameters, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !diff.PointerEqual(r.Area, old.Area) {
+		if !validation.IsSet(r.Area) {
+			errs = errors.Join(errs, validation.Invalid("area", validation.ErrRequired, "required", "field is required", nil))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
 	return nil
 }
@@ -2,13 +2,13 @@
 package v1alpha1
 
 import (
-	"fmt"
+	"github.com/henderiw/godantic/pkg/validation"
 )
 
 func (r AdminState) Validate() error {
 	valid := map[string]struct{}{"enable": {}, "maintenance": {}, "decommisioned": {}, "standby": {}}
 	if _, ok := valid[string(r)]; !ok {
-		return fmt.Errorf("invalid value for AdminState: %s", r)
+		return validation.Invalid("", validation.ErrEnum, "enum", "AdminState must be one of \"enable\", \"maintenance\", \"decommisioned\", \"standby\", got \"{value}\"", validation.Args{"value": r})
 	}
 	return nil
 }
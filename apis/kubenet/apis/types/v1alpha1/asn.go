@@ -0,0 +1,6 @@
+package v1alpha1
+
+// ASN is an autonomous system number.
+// +generate:validate
+// +validate(range(min = 1, max = 4294967295))
+type ASN uint32
@@ -0,0 +1,19 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import (
+	"errors"
+
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+func (r ASN) Validate() error {
+	var errs error
+	if r < 1.000000 {
+		errs = errors.Join(errs, validation.Invalid("", validation.ErrRange, "range.min", "must be greater than or equal to {min}, got {value}", validation.Args{"min": 1, "value": r}))
+	}
+	if r > 4294967295.000000 {
+		errs = errors.Join(errs, validation.Invalid("", validation.ErrRange, "range.max", "must be less than or equal to {max}, got {value}", validation.Args{"max": 4294967295, "value": r}))
+	}
+	return errs
+}
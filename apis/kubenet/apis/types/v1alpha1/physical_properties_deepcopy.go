@@ -0,0 +1,19 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import ()
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *PhysicalProperties) DeepCopyInto(out *PhysicalProperties) {
+	*out = *in
+}
+
+// DeepCopy creates a new PhysicalProperties by deep copying the receiver.
+func (in *PhysicalProperties) DeepCopy() *PhysicalProperties {
+	if in == nil {
+		return nil
+	}
+	out := new(PhysicalProperties)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,8 +1,91 @@
 // GENERATED CODE - DO NOT EDIT
 package v1alpha1
 
-import ()
+import (
+	"context"
+	"errors"
+
+	"github.com/henderiw/godantic/apis/meta/v1"
+	"github.com/henderiw/godantic/pkg/validation"
+)
 
 func (r *PhysicalProperties) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *PhysicalProperties) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *PhysicalProperties) ValidateContext(ctx context.Context, opts validation.Options) error {
+	var errs error
+	if err := v1.ValidateTime(r.PurshaseDate); err != nil {
+		errs = errors.Join(errs, validation.Prefix("purchaseDate", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *PhysicalProperties) ValidateUpdate(old *PhysicalProperties) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *PhysicalProperties) ValidateUpdateContext(ctx context.Context, old *PhysicalProperties, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if err := v1.ValidateTime(r.PurshaseDate); err != nil {
+		errs = errors.Join(errs, validation.Prefix("purchaseDate", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *PhysicalProperties) ValidateRatcheting(old *PhysicalProperties) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *PhysicalProperties) ValidateRatchetingContext(ctx context.Context, old *PhysicalProperties, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !validation.DeepEqual(r.PurshaseDate, old.PurshaseDate) {
+		if err := v1.ValidateTime(r.PurshaseDate); err != nil {
+			errs = errors.Join(errs, validation.Prefix("purchaseDate", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
 	return nil
 }
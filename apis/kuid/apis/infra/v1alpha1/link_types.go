@@ -23,13 +23,17 @@ import (
 )
 
 // LinkSpec defines the desired state of Link
+// A link runs a single IGP, OSPF or ISIS
 // +generate:validate
+// +union(members=[ospf,isis], mode=at_most_one)
+// +generate:equal
 type LinkSpec struct {
 	// +kubebuilder:storageversion
 
 	// Endpoints define the 2 endpoint identifiers of the link
 	// Can only have 2 endpoints
 	// +validate(length(equal = 2))
+	// +validate(unique(keys=[Kind,Name]))
 	Endpoints []*metav1.ObjectReference `json:"endpoints" protobuf:"bytes,1,opt,name=endpoints"`
 	// UserDefinedLabels define metadata to the resource.
 	// defined in the spec to distingiush metadata labels from user defined labels
@@ -50,6 +54,7 @@ type LinkSpec struct {
 
 // LinkStatus defines the observed state of Link
 // +generate:validate
+// +generate:equal
 type LinkStatus struct {
 	// ConditionedStatus provides the status of the IPClain using conditions
 	// - a ready condition indicates the overall status of the resource
@@ -65,6 +70,7 @@ type LinkStatus struct {
 // A link represents a physical/logical connection that enables communication and data transfer
 // between 2 endpoints of a node.
 // +generate:validate
+// +generate:equal
 type Link struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
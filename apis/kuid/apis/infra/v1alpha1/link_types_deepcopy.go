@@ -0,0 +1,117 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import (
+	kubenetnetworkv1alpha1 "github.com/henderiw/godantic/apis/kubenet/apis/network/v1alpha1"
+	metav1 "github.com/henderiw/godantic/apis/meta/v1"
+)
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *LinkSpec) DeepCopyInto(out *LinkSpec) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]*metav1.ObjectReference, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(metav1.ObjectReference)
+				**out = **in
+			}
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(kubenetnetworkv1alpha1.BFDLinkParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OSPF != nil {
+		in, out := &in.OSPF, &out.OSPF
+		*out = new(kubenetnetworkv1alpha1.OSPFLinkParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ISIS != nil {
+		in, out := &in.ISIS, &out.ISIS
+		*out = new(kubenetnetworkv1alpha1.ISISLinkParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BGP != nil {
+		in, out := &in.BGP, &out.BGP
+		*out = new(kubenetnetworkv1alpha1.BGPLinkParameters)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy creates a new LinkSpec by deep copying the receiver.
+func (in *LinkSpec) DeepCopy() *LinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *LinkStatus) DeepCopyInto(out *LinkStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+}
+
+// DeepCopy creates a new LinkStatus by deep copying the receiver.
+func (in *LinkStatus) DeepCopy() *LinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *Link) DeepCopyInto(out *Link) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy creates a new Link by deep copying the receiver.
+func (in *Link) DeepCopy() *Link {
+	if in == nil {
+		return nil
+	}
+	out := new(Link)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *LinkList) DeepCopyInto(out *LinkList) {
+	*out = *in
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Link, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy creates a new LinkList by deep copying the receiver.
+func (in *LinkList) DeepCopy() *LinkList {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkList)
+	in.DeepCopyInto(out)
+	return out
+}
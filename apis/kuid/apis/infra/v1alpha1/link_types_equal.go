@@ -0,0 +1,142 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import (
+	"maps"
+	"reflect"
+
+	"github.com/henderiw/godantic/pkg/diff"
+)
+
+// Equal returns true if the receiver and other are equal, ignoring the fields
+// marked with +equal(skip).
+func (r *LinkSpec) Equal(other *LinkSpec) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if len(r.Endpoints) != len(other.Endpoints) {
+		return false
+	}
+	for i := range r.Endpoints {
+		if !r.Endpoints[i].Equal(other.Endpoints[i]) {
+			return false
+		}
+	}
+	if !maps.Equal(r.Labels, other.Labels) {
+		return false
+	}
+	if !reflect.DeepEqual(r.BFD, other.BFD) {
+		return false
+	}
+	if !reflect.DeepEqual(r.OSPF, other.OSPF) {
+		return false
+	}
+	if !reflect.DeepEqual(r.ISIS, other.ISIS) {
+		return false
+	}
+	if !reflect.DeepEqual(r.BGP, other.BGP) {
+		return false
+	}
+	return true
+}
+
+// Diff returns the changes from the receiver to other, ignoring the fields
+// marked with +equal(skip).
+func (r *LinkSpec) Diff(other *LinkSpec) diff.Changes {
+	if r == nil || other == nil {
+		if r == other {
+			return nil
+		}
+		return diff.Changes{{Old: diff.Deref(r), New: diff.Deref(other)}}
+	}
+	var changes diff.Changes
+	if len(r.Endpoints) != len(other.Endpoints) {
+		changes = append(changes, diff.Change{Path: "endpoints", Old: r.Endpoints, New: other.Endpoints})
+	} else {
+		for i := range r.Endpoints {
+			changes = append(changes, r.Endpoints[i].Diff(other.Endpoints[i]).Prefix(diff.Index("endpoints", i))...)
+		}
+	}
+	changes = append(changes, diff.Map("labels", r.Labels, other.Labels)...)
+	if !reflect.DeepEqual(r.BFD, other.BFD) {
+		changes = append(changes, diff.Change{Path: "bfd", Old: diff.Deref(r.BFD), New: diff.Deref(other.BFD)})
+	}
+	if !reflect.DeepEqual(r.OSPF, other.OSPF) {
+		changes = append(changes, diff.Change{Path: "ospf", Old: diff.Deref(r.OSPF), New: diff.Deref(other.OSPF)})
+	}
+	if !reflect.DeepEqual(r.ISIS, other.ISIS) {
+		changes = append(changes, diff.Change{Path: "isis", Old: diff.Deref(r.ISIS), New: diff.Deref(other.ISIS)})
+	}
+	if !reflect.DeepEqual(r.BGP, other.BGP) {
+		changes = append(changes, diff.Change{Path: "bgp", Old: diff.Deref(r.BGP), New: diff.Deref(other.BGP)})
+	}
+	return changes
+}
+
+// Equal returns true if the receiver and other are equal, ignoring the fields
+// marked with +equal(skip).
+func (r *LinkStatus) Equal(other *LinkStatus) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if !r.ConditionedStatus.Equal(&other.ConditionedStatus) {
+		return false
+	}
+	return true
+}
+
+// Diff returns the changes from the receiver to other, ignoring the fields
+// marked with +equal(skip).
+func (r *LinkStatus) Diff(other *LinkStatus) diff.Changes {
+	if r == nil || other == nil {
+		if r == other {
+			return nil
+		}
+		return diff.Changes{{Old: diff.Deref(r), New: diff.Deref(other)}}
+	}
+	var changes diff.Changes
+	changes = append(changes, r.ConditionedStatus.Diff(&other.ConditionedStatus).Prefix("")...)
+	return changes
+}
+
+// Equal returns true if the receiver and other are equal, ignoring the fields
+// marked with +equal(skip).
+func (r *Link) Equal(other *Link) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if !reflect.DeepEqual(r.TypeMeta, other.TypeMeta) {
+		return false
+	}
+	if !reflect.DeepEqual(r.ObjectMeta, other.ObjectMeta) {
+		return false
+	}
+	if !r.Spec.Equal(&other.Spec) {
+		return false
+	}
+	if !r.Status.Equal(&other.Status) {
+		return false
+	}
+	return true
+}
+
+// Diff returns the changes from the receiver to other, ignoring the fields
+// marked with +equal(skip).
+func (r *Link) Diff(other *Link) diff.Changes {
+	if r == nil || other == nil {
+		if r == other {
+			return nil
+		}
+		return diff.Changes{{Old: diff.Deref(r), New: diff.Deref(other)}}
+	}
+	var changes diff.Changes
+	if !reflect.DeepEqual(r.TypeMeta, other.TypeMeta) {
+		changes = append(changes, diff.Change{Path: "", Old: r.TypeMeta, New: other.TypeMeta})
+	}
+	if !reflect.DeepEqual(r.ObjectMeta, other.ObjectMeta) {
+		changes = append(changes, diff.Change{Path: "metadata", Old: r.ObjectMeta, New: other.ObjectMeta})
+	}
+	changes = append(changes, r.Spec.Diff(&other.Spec).Prefix("spec")...)
+	changes = append(changes, r.Status.Diff(&other.Status).Prefix("status")...)
+	return changes
+}
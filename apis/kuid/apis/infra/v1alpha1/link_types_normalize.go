@@ -0,0 +1,51 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import (
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+// Normalize applies the transforms of the fields of the receiver and of its
+// nested values.
+func (r *LinkStatus) Normalize() {
+	r.NormalizeWith(validation.Options{})
+}
+
+// NormalizeWith normalizes the receiver like Normalize. The values of
+// recursive types are not normalized again within a cycle, nor nested deeper
+// than the maximum depth of the options.
+func (r *LinkStatus) NormalizeWith(opts validation.Options) {
+	if r == nil {
+		return
+	}
+	r.ConditionedStatus.NormalizeWith(opts)
+}
+
+// NormalizeAndValidate normalizes the receiver and validates the result.
+func (r *LinkStatus) NormalizeAndValidate() error {
+	r.Normalize()
+	return r.Validate()
+}
+
+// Normalize applies the transforms of the fields of the receiver and of its
+// nested values.
+func (r *Link) Normalize() {
+	r.NormalizeWith(validation.Options{})
+}
+
+// NormalizeWith normalizes the receiver like Normalize. The values of
+// recursive types are not normalized again within a cycle, nor nested deeper
+// than the maximum depth of the options.
+func (r *Link) NormalizeWith(opts validation.Options) {
+	if r == nil {
+		return
+	}
+	r.ObjectMeta.NormalizeWith(opts)
+	r.Status.NormalizeWith(opts)
+}
+
+// NormalizeAndValidate normalizes the receiver and validates the result.
+func (r *Link) NormalizeAndValidate() error {
+	r.Normalize()
+	return r.Validate()
+}
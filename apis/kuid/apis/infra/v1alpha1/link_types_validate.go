@@ -2,50 +2,497 @@
 package v1alpha1
 
 import (
+	"context"
 	"errors"
-	"fmt"
+
+	"github.com/henderiw/godantic/pkg/validation"
 )
 
 func (r *LinkSpec) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *LinkSpec) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *LinkSpec) ValidateContext(ctx context.Context, opts validation.Options) error {
 	var errs error
 	if len(r.Endpoints) != 2 {
-		errs = errors.Join(errs, fmt.Errorf("len Endpoints must be = %d", 2))
+		errs = errors.Join(errs, validation.Invalid("endpoints", validation.ErrLength, "length.equal", "length must be {equal}, got {len}", validation.Args{"equal": 2, "len": len(r.Endpoints)}))
+	}
+	{
+		seen := make(map[any]int, len(r.Endpoints))
+		for j := range r.Endpoints {
+			if r.Endpoints[j] == nil {
+				continue
+			}
+			key := [2]any{r.Endpoints[j].Kind, r.Endpoints[j].Name}
+			if first, ok := seen[key]; ok {
+				errs = errors.Join(errs, validation.Invalid(validation.Index("endpoints", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+				continue
+			}
+			seen[key] = j
+		}
 	}
-	for _, item := range r.Endpoints {
+	for i, item := range r.Endpoints {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(errs, err)
+		}
 		if item != nil {
-			if err := item.Validate(); err != nil {
-				errs = errors.Join(errs, err)
+			if err := item.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+				errs = errors.Join(errs, validation.Prefix(validation.Index("endpoints", i), err))
 			}
 		}
+		if opts.Exceeded(errs) {
+			return errs
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
 	}
 	if r.BFD != nil {
-		if err := r.BFD.Validate(); err != nil {
-			errs = errors.Join(errs, err)
+		if err := r.BFD.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("bfd", err))
 		}
 	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
 	if r.OSPF != nil {
-		if err := r.OSPF.Validate(); err != nil {
-			errs = errors.Join(errs, err)
+		if err := r.OSPF.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("ospf", err))
 		}
 	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
 	if r.ISIS != nil {
-		if err := r.ISIS.Validate(); err != nil {
-			errs = errors.Join(errs, err)
+		if err := r.ISIS.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("isis", err))
 		}
 	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
 	if r.BGP != nil {
-		if err := r.BGP.Validate(); err != nil {
-			errs = errors.Join(errs, err)
+		if err := r.BGP.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("bgp", err))
 		}
 	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	// at most one of ospf, isis is set
+	errs = errors.Join(errs, validation.Union{
+		Members: []string{"ospf", "isis"},
+	}.Check([]bool{validation.IsSet(r.OSPF), validation.IsSet(r.ISIS)}, ""))
+	if opts.Exceeded(errs) {
+		return errs
+	}
 	if errs != nil {
 		return errs
 	}
 	return nil
 }
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *LinkSpec) ValidateUpdate(old *LinkSpec) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *LinkSpec) ValidateUpdateContext(ctx context.Context, old *LinkSpec, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if len(r.Endpoints) != 2 {
+		errs = errors.Join(errs, validation.Invalid("endpoints", validation.ErrLength, "length.equal", "length must be {equal}, got {len}", validation.Args{"equal": 2, "len": len(r.Endpoints)}))
+	}
+	{
+		seen := make(map[any]int, len(r.Endpoints))
+		for j := range r.Endpoints {
+			if r.Endpoints[j] == nil {
+				continue
+			}
+			key := [2]any{r.Endpoints[j].Kind, r.Endpoints[j].Name}
+			if first, ok := seen[key]; ok {
+				errs = errors.Join(errs, validation.Invalid(validation.Index("endpoints", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+				continue
+			}
+			seen[key] = j
+		}
+	}
+	for i, item := range r.Endpoints {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(errs, err)
+		}
+		oldItem := validation.PointerAt(old.Endpoints, i)
+		if item != nil {
+			if err := item.ValidateUpdateContext(ctx, oldItem, opts.Nested(errs)); err != nil {
+				errs = errors.Join(errs, validation.Prefix(validation.Index("endpoints", i), err))
+			}
+		}
+		if opts.Exceeded(errs) {
+			return errs
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if r.BFD != nil {
+		if err := r.BFD.ValidateUpdateContext(ctx, old.BFD, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("bfd", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if r.OSPF != nil {
+		if err := r.OSPF.ValidateUpdateContext(ctx, old.OSPF, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("ospf", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if r.ISIS != nil {
+		if err := r.ISIS.ValidateUpdateContext(ctx, old.ISIS, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("isis", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if r.BGP != nil {
+		if err := r.BGP.ValidateUpdateContext(ctx, old.BGP, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("bgp", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	// at most one of ospf, isis is set
+	errs = errors.Join(errs, validation.Union{
+		Members: []string{"ospf", "isis"},
+	}.Check([]bool{validation.IsSet(r.OSPF), validation.IsSet(r.ISIS)}, ""))
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *LinkSpec) ValidateRatcheting(old *LinkSpec) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *LinkSpec) ValidateRatchetingContext(ctx context.Context, old *LinkSpec, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !validation.DeepEqual(r.Endpoints, old.Endpoints) {
+		if len(r.Endpoints) != 2 {
+			errs = errors.Join(errs, validation.Invalid("endpoints", validation.ErrLength, "length.equal", "length must be {equal}, got {len}", validation.Args{"equal": 2, "len": len(r.Endpoints)}))
+		}
+		{
+			seen := make(map[any]int, len(r.Endpoints))
+			for j := range r.Endpoints {
+				if r.Endpoints[j] == nil {
+					continue
+				}
+				key := [2]any{r.Endpoints[j].Kind, r.Endpoints[j].Name}
+				if first, ok := seen[key]; ok {
+					errs = errors.Join(errs, validation.Invalid(validation.Index("endpoints", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+					continue
+				}
+				seen[key] = j
+			}
+		}
+		for i, item := range r.Endpoints {
+			if err := ctx.Err(); err != nil {
+				return errors.Join(errs, err)
+			}
+			if item != nil {
+				if err := item.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+					errs = errors.Join(errs, validation.Prefix(validation.Index("endpoints", i), err))
+				}
+			}
+			if opts.Exceeded(errs) {
+				return errs
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.BFD, old.BFD) {
+		if r.BFD != nil {
+			if err := r.BFD.ValidateRatchetingContext(ctx, old.BFD, opts.Nested(errs)); err != nil {
+				errs = errors.Join(errs, validation.Prefix("bfd", err))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.OSPF, old.OSPF) {
+		if r.OSPF != nil {
+			if err := r.OSPF.ValidateRatchetingContext(ctx, old.OSPF, opts.Nested(errs)); err != nil {
+				errs = errors.Join(errs, validation.Prefix("ospf", err))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.ISIS, old.ISIS) {
+		if r.ISIS != nil {
+			if err := r.ISIS.ValidateRatchetingContext(ctx, old.ISIS, opts.Nested(errs)); err != nil {
+				errs = errors.Join(errs, validation.Prefix("isis", err))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.BGP, old.BGP) {
+		if r.BGP != nil {
+			if err := r.BGP.ValidateRatchetingContext(ctx, old.BGP, opts.Nested(errs)); err != nil {
+				errs = errors.Join(errs, validation.Prefix("bgp", err))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.OSPF, old.OSPF) || !validation.DeepEqual(r.ISIS, old.ISIS) {
+		// at most one of ospf, isis is set
+		errs = errors.Join(errs, validation.Union{
+			Members: []string{"ospf", "isis"},
+		}.Check([]bool{validation.IsSet(r.OSPF), validation.IsSet(r.ISIS)}, ""))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
 func (r *LinkStatus) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *LinkStatus) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *LinkStatus) ValidateContext(ctx context.Context, opts validation.Options) error {
+	var errs error
+	if err := r.ConditionedStatus.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *LinkStatus) ValidateUpdate(old *LinkStatus) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *LinkStatus) ValidateUpdateContext(ctx context.Context, old *LinkStatus, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if err := r.ConditionedStatus.ValidateUpdateContext(ctx, &old.ConditionedStatus, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *LinkStatus) ValidateRatcheting(old *LinkStatus) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *LinkStatus) ValidateRatchetingContext(ctx context.Context, old *LinkStatus, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !validation.DeepEqual(r.ConditionedStatus, old.ConditionedStatus) {
+		if err := r.ConditionedStatus.ValidateRatchetingContext(ctx, &old.ConditionedStatus, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
 	return nil
 }
+
 func (r *Link) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *Link) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *Link) ValidateContext(ctx context.Context, opts validation.Options) error {
+	if opts.Has(validation.GroupStatus) {
+		// the status subresource only validates the status
+		return validation.Prefix("status", r.Status.ValidateContext(ctx, opts))
+	}
+	var errs error
+	if err := r.ObjectMeta.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, validation.Prefix("metadata", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if err := r.Spec.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, validation.Prefix("spec", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	// the status is only updated through the status subresource
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *Link) ValidateUpdate(old *Link) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *Link) ValidateUpdateContext(ctx context.Context, old *Link, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if err := r.ObjectMeta.ValidateUpdateContext(ctx, &old.ObjectMeta, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, validation.Prefix("metadata", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if err := r.Spec.ValidateUpdateContext(ctx, &old.Spec, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, validation.Prefix("spec", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	// the status is only updated through the status subresource
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *Link) ValidateRatcheting(old *Link) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *Link) ValidateRatchetingContext(ctx context.Context, old *Link, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !validation.DeepEqual(r.ObjectMeta, old.ObjectMeta) {
+		if err := r.ObjectMeta.ValidateRatchetingContext(ctx, &old.ObjectMeta, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("metadata", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.Spec, old.Spec) {
+		if err := r.Spec.ValidateRatchetingContext(ctx, &old.Spec, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("spec", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	// the status is only updated through the status subresource
+	if errs != nil {
+		return errs
+	}
 	return nil
 }
+
+// ValidateStatusUpdate validates the receiver as an update of old through the
+// status subresource, which only updates the status.
+func (r *Link) ValidateStatusUpdate(old *Link) error {
+	opts := validation.Options{Groups: []string{validation.GroupUpdate, validation.GroupStatus}}
+	if old == nil {
+		return r.ValidateWith(opts)
+	}
+	return validation.Prefix("status", r.Status.ValidateUpdateContext(context.Background(), &old.Status, opts))
+}
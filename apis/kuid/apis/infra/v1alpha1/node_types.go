@@ -8,15 +8,18 @@ import (
 
 // NodeSpec defines the desired state of Node
 // +generate:validate
+// +generate:equal
 type NodeSpec struct {
 	// TBD: Do we need a name here or not ??? -> right now we assume we use the name of the resource
 	// the name should be defined that is unique within the system -> k8s constraint
 
 	// Node defines the name of the node
+	// +transform(trim)
 	// +validate(length(min = 10))
 	Node *string `json:"node"`
 
 	// *** Static immutable below ***
+	// +validate(immutable)
 	PhysicalProperties kubenettypesv1alpha1.PhysicalProperties `json:",inline"`
 	// *** Static immutable above ***
 
@@ -33,6 +36,8 @@ type NodeSpec struct {
 	Location *kubenettypesv1alpha1.Location `json:"location,omitempty"`
 
 	// Provider defines the provider implementing this resource.
+	// +validate(immutable_once_set)
+	// +validate(length(min = 1), groups=[create])
 	Provider *string `json:"provider,omitempty"`
 
 	// Version define the SW version of the node
@@ -41,12 +46,14 @@ type NodeSpec struct {
 
 // NodeStatus defines the observed state of Node
 // +generate:validate
+// +generate:equal
 type NodeStatus struct {
 	// ConditionedStatus provides the status of the IPClain using conditions
 	// - a ready condition indicates the overall status of the resource
 	metav1.ConditionedStatus `json:",inline" protobuf:"bytes,1,opt,name=conditionedStatus"`
 	// System ID define the unique system id of the node
 	// +optional
+	// +validate(length(min = 1), groups=[status])
 	SystemID *string `json:"systemID,omitempty" protobuf:"bytes,2,opt,name=systemID"`
 }
 
@@ -64,6 +71,7 @@ type NodeStatus struct {
 // management and control within defined administrative boundaries.
 // Each Node is assigned a provider, representing the entity responsible for implementing the specifics of the Node.
 // +generate:validate
+// +generate:equal
 type Node struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
@@ -0,0 +1,145 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import (
+	"maps"
+	"reflect"
+
+	"github.com/henderiw/godantic/pkg/diff"
+)
+
+// Equal returns true if the receiver and other are equal, ignoring the fields
+// marked with +equal(skip).
+func (r *NodeSpec) Equal(other *NodeSpec) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if !diff.PointerEqual(r.Node, other.Node) {
+		return false
+	}
+	if !reflect.DeepEqual(r.PhysicalProperties, other.PhysicalProperties) {
+		return false
+	}
+	if r.AdminState != other.AdminState {
+		return false
+	}
+	if !maps.Equal(r.Labels, other.Labels) {
+		return false
+	}
+	if !reflect.DeepEqual(r.Location, other.Location) {
+		return false
+	}
+	if !diff.PointerEqual(r.Provider, other.Provider) {
+		return false
+	}
+	if !diff.PointerEqual(r.Version, other.Version) {
+		return false
+	}
+	return true
+}
+
+// Diff returns the changes from the receiver to other, ignoring the fields
+// marked with +equal(skip).
+func (r *NodeSpec) Diff(other *NodeSpec) diff.Changes {
+	if r == nil || other == nil {
+		if r == other {
+			return nil
+		}
+		return diff.Changes{{Old: diff.Deref(r), New: diff.Deref(other)}}
+	}
+	var changes diff.Changes
+	if !diff.PointerEqual(r.Node, other.Node) {
+		changes = append(changes, diff.Change{Path: "node", Old: diff.Deref(r.Node), New: diff.Deref(other.Node)})
+	}
+	if !reflect.DeepEqual(r.PhysicalProperties, other.PhysicalProperties) {
+		changes = append(changes, diff.Change{Path: "PhysicalProperties", Old: r.PhysicalProperties, New: other.PhysicalProperties})
+	}
+	if r.AdminState != other.AdminState {
+		changes = append(changes, diff.Change{Path: "adminState", Old: r.AdminState, New: other.AdminState})
+	}
+	changes = append(changes, diff.Map("labels", r.Labels, other.Labels)...)
+	if !reflect.DeepEqual(r.Location, other.Location) {
+		changes = append(changes, diff.Change{Path: "location", Old: diff.Deref(r.Location), New: diff.Deref(other.Location)})
+	}
+	if !diff.PointerEqual(r.Provider, other.Provider) {
+		changes = append(changes, diff.Change{Path: "provider", Old: diff.Deref(r.Provider), New: diff.Deref(other.Provider)})
+	}
+	if !diff.PointerEqual(r.Version, other.Version) {
+		changes = append(changes, diff.Change{Path: "version", Old: diff.Deref(r.Version), New: diff.Deref(other.Version)})
+	}
+	return changes
+}
+
+// Equal returns true if the receiver and other are equal, ignoring the fields
+// marked with +equal(skip).
+func (r *NodeStatus) Equal(other *NodeStatus) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if !r.ConditionedStatus.Equal(&other.ConditionedStatus) {
+		return false
+	}
+	if !diff.PointerEqual(r.SystemID, other.SystemID) {
+		return false
+	}
+	return true
+}
+
+// Diff returns the changes from the receiver to other, ignoring the fields
+// marked with +equal(skip).
+func (r *NodeStatus) Diff(other *NodeStatus) diff.Changes {
+	if r == nil || other == nil {
+		if r == other {
+			return nil
+		}
+		return diff.Changes{{Old: diff.Deref(r), New: diff.Deref(other)}}
+	}
+	var changes diff.Changes
+	changes = append(changes, r.ConditionedStatus.Diff(&other.ConditionedStatus).Prefix("")...)
+	if !diff.PointerEqual(r.SystemID, other.SystemID) {
+		changes = append(changes, diff.Change{Path: "systemID", Old: diff.Deref(r.SystemID), New: diff.Deref(other.SystemID)})
+	}
+	return changes
+}
+
+// Equal returns true if the receiver and other are equal, ignoring the fields
+// marked with +equal(skip).
+func (r *Node) Equal(other *Node) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if !reflect.DeepEqual(r.TypeMeta, other.TypeMeta) {
+		return false
+	}
+	if !reflect.DeepEqual(r.ObjectMeta, other.ObjectMeta) {
+		return false
+	}
+	if !r.Spec.Equal(&other.Spec) {
+		return false
+	}
+	if !r.Status.Equal(&other.Status) {
+		return false
+	}
+	return true
+}
+
+// Diff returns the changes from the receiver to other, ignoring the fields
+// marked with +equal(skip).
+func (r *Node) Diff(other *Node) diff.Changes {
+	if r == nil || other == nil {
+		if r == other {
+			return nil
+		}
+		return diff.Changes{{Old: diff.Deref(r), New: diff.Deref(other)}}
+	}
+	var changes diff.Changes
+	if !reflect.DeepEqual(r.TypeMeta, other.TypeMeta) {
+		changes = append(changes, diff.Change{Path: "", Old: r.TypeMeta, New: other.TypeMeta})
+	}
+	if !reflect.DeepEqual(r.ObjectMeta, other.ObjectMeta) {
+		changes = append(changes, diff.Change{Path: "metadata", Old: r.ObjectMeta, New: other.ObjectMeta})
+	}
+	changes = append(changes, r.Spec.Diff(&other.Spec).Prefix("spec")...)
+	changes = append(changes, r.Status.Diff(&other.Status).Prefix("status")...)
+	return changes
+}
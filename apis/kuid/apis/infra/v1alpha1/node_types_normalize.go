@@ -0,0 +1,76 @@
+// GENERATED CODE - DO NOT EDIT
+package v1alpha1
+
+import (
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+// Normalize applies the transforms of the fields of the receiver and of its
+// nested values.
+func (r *NodeSpec) Normalize() {
+	r.NormalizeWith(validation.Options{})
+}
+
+// NormalizeWith normalizes the receiver like Normalize. The values of
+// recursive types are not normalized again within a cycle, nor nested deeper
+// than the maximum depth of the options.
+func (r *NodeSpec) NormalizeWith(opts validation.Options) {
+	if r == nil {
+		return
+	}
+	if r.Node != nil {
+		*r.Node = validation.TrimSpace(*r.Node)
+	}
+}
+
+// NormalizeAndValidate normalizes the receiver and validates the result.
+func (r *NodeSpec) NormalizeAndValidate() error {
+	r.Normalize()
+	return r.Validate()
+}
+
+// Normalize applies the transforms of the fields of the receiver and of its
+// nested values.
+func (r *NodeStatus) Normalize() {
+	r.NormalizeWith(validation.Options{})
+}
+
+// NormalizeWith normalizes the receiver like Normalize. The values of
+// recursive types are not normalized again within a cycle, nor nested deeper
+// than the maximum depth of the options.
+func (r *NodeStatus) NormalizeWith(opts validation.Options) {
+	if r == nil {
+		return
+	}
+	r.ConditionedStatus.NormalizeWith(opts)
+}
+
+// NormalizeAndValidate normalizes the receiver and validates the result.
+func (r *NodeStatus) NormalizeAndValidate() error {
+	r.Normalize()
+	return r.Validate()
+}
+
+// Normalize applies the transforms of the fields of the receiver and of its
+// nested values.
+func (r *Node) Normalize() {
+	r.NormalizeWith(validation.Options{})
+}
+
+// NormalizeWith normalizes the receiver like Normalize. The values of
+// recursive types are not normalized again within a cycle, nor nested deeper
+// than the maximum depth of the options.
+func (r *Node) NormalizeWith(opts validation.Options) {
+	if r == nil {
+		return
+	}
+	r.ObjectMeta.NormalizeWith(opts)
+	r.Spec.NormalizeWith(opts)
+	r.Status.NormalizeWith(opts)
+}
+
+// NormalizeAndValidate normalizes the receiver and validates the result.
+func (r *Node) NormalizeAndValidate() error {
+	r.Normalize()
+	return r.Validate()
+}
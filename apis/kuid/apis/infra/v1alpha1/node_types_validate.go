@@ -2,36 +2,437 @@
 package v1alpha1
 
 import (
+	"context"
 	"errors"
-	"fmt"
+
+	"github.com/henderiw/godantic/pkg/diff"
+	"github.com/henderiw/godantic/pkg/validation"
 )
 
 func (r *NodeSpec) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *NodeSpec) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *NodeSpec) ValidateContext(ctx context.Context, opts validation.Options) error {
 	var errs error
 	if r.Node != nil {
 		if len(*r.Node) < 10 {
-			errs = errors.Join(errs, fmt.Errorf("len *Node must be > %d", 10))
+			errs = errors.Join(errs, validation.Invalid("node", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 10, "len": len(*r.Node)}))
 		}
 	}
-	if err := r.PhysicalProperties.Validate(); err != nil {
-		errs = errors.Join(errs, err)
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if err := r.PhysicalProperties.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, validation.Prefix("PhysicalProperties", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
 	}
 	if err := r.AdminState.Validate(); err != nil {
-		errs = errors.Join(errs, err)
+		errs = errors.Join(errs, validation.Prefix("adminState", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
 	}
 	if r.Location != nil {
-		if err := r.Location.Validate(); err != nil {
-			errs = errors.Join(errs, err)
+		if err := r.Location.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("location", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if opts.Has("create") {
+		if r.Provider != nil {
+			if len(*r.Provider) < 1 {
+				errs = errors.Join(errs, validation.Invalid("provider", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(*r.Provider)}))
+			}
 		}
 	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
 	if errs != nil {
 		return errs
 	}
 	return nil
 }
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *NodeSpec) ValidateUpdate(old *NodeSpec) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *NodeSpec) ValidateUpdateContext(ctx context.Context, old *NodeSpec, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if r.Node != nil {
+		if len(*r.Node) < 10 {
+			errs = errors.Join(errs, validation.Invalid("node", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 10, "len": len(*r.Node)}))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if err := r.PhysicalProperties.ValidateUpdateContext(ctx, &old.PhysicalProperties, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, validation.Prefix("PhysicalProperties", err))
+	}
+	if !validation.DeepEqual(r.PhysicalProperties, old.PhysicalProperties) {
+		errs = errors.Join(errs, validation.Immutable("PhysicalProperties"))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if err := r.AdminState.Validate(); err != nil {
+		errs = errors.Join(errs, validation.Prefix("adminState", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if r.Location != nil {
+		if err := r.Location.ValidateUpdateContext(ctx, old.Location, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("location", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if opts.Has("create") {
+		if r.Provider != nil {
+			if len(*r.Provider) < 1 {
+				errs = errors.Join(errs, validation.Invalid("provider", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(*r.Provider)}))
+			}
+		}
+	}
+	if !validation.IsZero(old.Provider) && !diff.PointerEqual(r.Provider, old.Provider) {
+		errs = errors.Join(errs, validation.Immutable("provider"))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *NodeSpec) ValidateRatcheting(old *NodeSpec) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *NodeSpec) ValidateRatchetingContext(ctx context.Context, old *NodeSpec, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !diff.PointerEqual(r.Node, old.Node) {
+		if r.Node != nil {
+			if len(*r.Node) < 10 {
+				errs = errors.Join(errs, validation.Invalid("node", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 10, "len": len(*r.Node)}))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.PhysicalProperties, old.PhysicalProperties) {
+		if err := r.PhysicalProperties.ValidateRatchetingContext(ctx, &old.PhysicalProperties, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("PhysicalProperties", err))
+		}
+	}
+	if !validation.DeepEqual(r.PhysicalProperties, old.PhysicalProperties) {
+		errs = errors.Join(errs, validation.Immutable("PhysicalProperties"))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if r.AdminState != old.AdminState {
+		if err := r.AdminState.Validate(); err != nil {
+			errs = errors.Join(errs, validation.Prefix("adminState", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.Location, old.Location) {
+		if r.Location != nil {
+			if err := r.Location.ValidateRatchetingContext(ctx, old.Location, opts.Nested(errs)); err != nil {
+				errs = errors.Join(errs, validation.Prefix("location", err))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !diff.PointerEqual(r.Provider, old.Provider) {
+		if opts.Has("create") {
+			if r.Provider != nil {
+				if len(*r.Provider) < 1 {
+					errs = errors.Join(errs, validation.Invalid("provider", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(*r.Provider)}))
+				}
+			}
+		}
+	}
+	if !validation.IsZero(old.Provider) && !diff.PointerEqual(r.Provider, old.Provider) {
+		errs = errors.Join(errs, validation.Immutable("provider"))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
 func (r *NodeStatus) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *NodeStatus) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *NodeStatus) ValidateContext(ctx context.Context, opts validation.Options) error {
+	var errs error
+	if err := r.ConditionedStatus.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if opts.Has("status") {
+		if r.SystemID != nil {
+			if len(*r.SystemID) < 1 {
+				errs = errors.Join(errs, validation.Invalid("systemID", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(*r.SystemID)}))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *NodeStatus) ValidateUpdate(old *NodeStatus) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *NodeStatus) ValidateUpdateContext(ctx context.Context, old *NodeStatus, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if err := r.ConditionedStatus.ValidateUpdateContext(ctx, &old.ConditionedStatus, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if opts.Has("status") {
+		if r.SystemID != nil {
+			if len(*r.SystemID) < 1 {
+				errs = errors.Join(errs, validation.Invalid("systemID", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(*r.SystemID)}))
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
 	return nil
 }
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *NodeStatus) ValidateRatcheting(old *NodeStatus) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *NodeStatus) ValidateRatchetingContext(ctx context.Context, old *NodeStatus, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !validation.DeepEqual(r.ConditionedStatus, old.ConditionedStatus) {
+		if err := r.ConditionedStatus.ValidateRatchetingContext(ctx, &old.ConditionedStatus, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !diff.PointerEqual(r.SystemID, old.SystemID) {
+		if opts.Has("status") {
+			if r.SystemID != nil {
+				if len(*r.SystemID) < 1 {
+					errs = errors.Join(errs, validation.Invalid("systemID", validation.ErrLength, "length.min", "length must be at least {min}, got {len}", validation.Args{"min": 1, "len": len(*r.SystemID)}))
+				}
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
 func (r *Node) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *Node) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *Node) ValidateContext(ctx context.Context, opts validation.Options) error {
+	if opts.Has(validation.GroupStatus) {
+		// the status subresource only validates the status
+		return validation.Prefix("status", r.Status.ValidateContext(ctx, opts))
+	}
+	var errs error
+	if err := r.ObjectMeta.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, validation.Prefix("metadata", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if err := r.Spec.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, validation.Prefix("spec", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	// the status is only updated through the status subresource
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *Node) ValidateUpdate(old *Node) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *Node) ValidateUpdateContext(ctx context.Context, old *Node, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if err := r.ObjectMeta.ValidateUpdateContext(ctx, &old.ObjectMeta, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, validation.Prefix("metadata", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if err := r.Spec.ValidateUpdateContext(ctx, &old.Spec, opts.Nested(errs)); err != nil {
+		errs = errors.Join(errs, validation.Prefix("spec", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	// the status is only updated through the status subresource
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *Node) ValidateRatcheting(old *Node) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *Node) ValidateRatchetingContext(ctx context.Context, old *Node, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if !validation.DeepEqual(r.ObjectMeta, old.ObjectMeta) {
+		if err := r.ObjectMeta.ValidateRatchetingContext(ctx, &old.ObjectMeta, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("metadata", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.Spec, old.Spec) {
+		if err := r.Spec.ValidateRatchetingContext(ctx, &old.Spec, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix("spec", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	// the status is only updated through the status subresource
+	if errs != nil {
+		return errs
+	}
 	return nil
 }
+
+// ValidateStatusUpdate validates the receiver as an update of old through the
+// status subresource, which only updates the status.
+func (r *Node) ValidateStatusUpdate(old *Node) error {
+	opts := validation.Options{Groups: []string{validation.GroupUpdate, validation.GroupStatus}}
+	if old == nil {
+		return r.ValidateWith(opts)
+	}
+	return validation.Prefix("status", r.Status.ValidateUpdateContext(context.Background(), &old.Status, opts))
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package v1
 
 import (
-	"sort"
 	"time"
 )
 
@@ -56,6 +55,8 @@ const (
 )
 
 // +generate:validate
+// +generate:equal
+// +equal(value)
 type Condition struct {
 	// type of condition in CamelCase or in foo.example.com/CamelCase.
 	// ---
@@ -84,7 +85,7 @@ type Condition struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Format=date-time
-	// +validate(skip)
+	// +equal(skip)
 	LastTransitionTime time.Time `json:"lastTransitionTime"`
 	// reason contains a programmatic identifier indicating the reason for the condition's last transition.
 	// Producers of specific condition types may define expected values and meanings for this field,
@@ -105,15 +106,6 @@ type Condition struct {
 	Message string `json:"message"`
 }
 
-// Equal returns true if the condition is identical to the supplied condition,
-// ignoring the LastTransitionTime.
-func (c Condition) Equal(other Condition) bool {
-	return c.Type == other.Type &&
-		c.Status == other.Status &&
-		c.Reason == other.Reason &&
-		c.Message == other.Message
-}
-
 // WithMessage returns a condition by adding the provided message to existing
 // condition.
 func (c Condition) WithMessage(msg string) Condition {
@@ -121,6 +113,13 @@ func (c Condition) WithMessage(msg string) Condition {
 	return c
 }
 
+// WithObservedGeneration returns a condition by setting the provided
+// generation as the observed generation of the existing condition.
+func (c Condition) WithObservedGeneration(gen int64) Condition {
+	c.ObservedGeneration = gen
+	return c
+}
+
 func (c Condition) IsTrue() bool {
 	return c.Status == ConditionTrue
 }
@@ -128,11 +127,13 @@ func (c Condition) IsTrue() bool {
 // A ConditionedStatus reflects the observed status of a resource. Only
 // one condition of each type may exist.
 // +generate:validate
+// +generate:equal
 type ConditionedStatus struct {
 	// Conditions of the resource.
 	// +optional
 	// +listType:=map
 	// +listMapKey:=type
+	// +transform(sort)
 	Conditions []Condition `json:"conditions,omitempty" protobuf:"bytes,1,rep,name=conditions"`
 }
 
@@ -164,58 +165,95 @@ func (r *ConditionedStatus) GetCondition(t ConditionType) Condition {
 	return Condition{Type: string(t), Status: ConditionFalse}
 }
 
+// FindCondition returns the condition of the given type, or nil if the
+// condition is not set. The returned condition points into the status and
+// can be modified in place.
+func (r *ConditionedStatus) FindCondition(t ConditionType) *Condition {
+	for i := range r.Conditions {
+		if r.Conditions[i].Type == string(t) {
+			return &r.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition sets the supplied condition, replacing an existing condition
+// of the same type. The LastTransitionTime of an existing condition is only
+// updated when its status changes; it is taken from the supplied condition
+// when set and from the current time otherwise. SetCondition returns true if
+// the conditions changed.
+func (r *ConditionedStatus) SetCondition(c Condition) bool {
+	return r.SetConditionWithClock(ClockFunc(time.Now), c)
+}
+
+// SetConditionWithClock sets the supplied condition like SetCondition, taking
+// the LastTransitionTime from the supplied clock when the condition has none.
+func (r *ConditionedStatus) SetConditionWithClock(clock Clock, c Condition) bool {
+	existing := r.FindCondition(ConditionType(c.Type))
+	if existing == nil {
+		if c.LastTransitionTime.IsZero() {
+			c.LastTransitionTime = clock.Now()
+		}
+		r.Conditions = append(r.Conditions, c)
+		return true
+	}
+
+	changed := false
+	if existing.Status != c.Status {
+		existing.Status = c.Status
+		if c.LastTransitionTime.IsZero() {
+			existing.LastTransitionTime = clock.Now()
+		} else {
+			existing.LastTransitionTime = c.LastTransitionTime
+		}
+		changed = true
+	}
+	if existing.Reason != c.Reason {
+		existing.Reason = c.Reason
+		changed = true
+	}
+	if existing.Message != c.Message {
+		existing.Message = c.Message
+		changed = true
+	}
+	if existing.ObservedGeneration != c.ObservedGeneration {
+		existing.ObservedGeneration = c.ObservedGeneration
+		changed = true
+	}
+	return changed
+}
+
 // SetConditions sets the supplied conditions, replacing any existing conditions
 // of the same type. This is a no-op if all supplied conditions are identical,
 // ignoring the last transition time, to those already set.
 func (r *ConditionedStatus) SetConditions(c ...Condition) {
 	for _, new := range c {
-		exists := false
-		for i, existing := range r.Conditions {
-			if existing.Type != new.Type {
-				continue
-			}
-
-			if existing.Equal(new) {
-				exists = true
-				continue
-			}
-
-			r.Conditions[i] = new
-			exists = true
-		}
-		if !exists {
-			r.Conditions = append(r.Conditions, new)
-		}
+		r.SetCondition(new)
 	}
 }
 
-// Equal returns true if the status is identical to the supplied status,
-// ignoring the LastTransitionTimes and order of statuses.
-func (r *ConditionedStatus) Equal(other *ConditionedStatus) bool {
-	if r == nil || other == nil {
-		return r == nil && other == nil
+// SetConditionsForGeneration sets the supplied conditions like SetConditions,
+// recording the generation of the supplied object metadata as their observed
+// generation. Nothing is set when meta is nil.
+func (r *ConditionedStatus) SetConditionsForGeneration(meta *ObjectMeta, c ...Condition) {
+	if meta == nil {
+		return
 	}
-
-	if len(other.Conditions) != len(r.Conditions) {
-		return false
+	for _, new := range c {
+		r.SetCondition(new.WithObservedGeneration(meta.Generation))
 	}
+}
 
-	sc := make([]Condition, len(r.Conditions))
-	copy(sc, r.Conditions)
-
-	oc := make([]Condition, len(other.Conditions))
-	copy(oc, other.Conditions)
-
-	// We should not have more than one condition of each type.
-	sort.Slice(sc, func(i, j int) bool { return sc[i].Type < sc[j].Type })
-	sort.Slice(oc, func(i, j int) bool { return oc[i].Type < oc[j].Type })
-
-	for i := range sc {
-		if !sc[i].Equal(oc[i]) {
-			return false
+// RemoveCondition removes the condition of the given type. It returns true if
+// the condition was set.
+func (r *ConditionedStatus) RemoveCondition(t ConditionType) bool {
+	for i, c := range r.Conditions {
+		if c.Type == string(t) {
+			r.Conditions = append(r.Conditions[:i], r.Conditions[i+1:]...)
+			return true
 		}
 	}
-	return true
+	return false
 }
 
 func (r *ConditionedStatus) IsConditionTrue(t ConditionType) bool {
@@ -223,14 +261,27 @@ func (r *ConditionedStatus) IsConditionTrue(t ConditionType) bool {
 	return c.Status == ConditionTrue
 }
 
+func (r *ConditionedStatus) IsConditionFalse(t ConditionType) bool {
+	c := r.FindCondition(t)
+	return c != nil && c.Status == ConditionFalse
+}
+
+// IsConditionObserved returns true if the condition is set and was set based
+// upon the current generation of the supplied object metadata. It returns
+// false when meta is nil.
+func (r *ConditionedStatus) IsConditionObserved(t ConditionType, meta *ObjectMeta) bool {
+	c := r.FindCondition(t)
+	return c != nil && meta != nil && c.ObservedGeneration == meta.Generation
+}
+
 // Ready returns a condition that indicates the resource is
-// ready for use.
+// ready for use. Its LastTransitionTime is stamped when it is set on a
+// status, like the conditions returned by Unknown and Failed.
 func Ready() Condition {
 	return Condition{
-		Type:               string(ConditionTypeReady),
-		Status:             ConditionTrue,
-		LastTransitionTime: time.Now(),
-		Reason:             string(ConditionReasonReady),
+		Type:   string(ConditionTypeReady),
+		Status: ConditionTrue,
+		Reason: string(ConditionReasonReady),
 	}
 }
 
@@ -238,10 +289,9 @@ func Ready() Condition {
 // unknown status.
 func Unknown() Condition {
 	return Condition{
-		Type:               string(ConditionTypeReady),
-		Status:             ConditionFalse,
-		LastTransitionTime: time.Now(),
-		Reason:             string(ConditionReasonUnknown),
+		Type:   string(ConditionTypeReady),
+		Status: ConditionFalse,
+		Reason: string(ConditionReasonUnknown),
 	}
 }
 
@@ -249,10 +299,9 @@ func Unknown() Condition {
 // failed to get reconciled.
 func Failed(msg string) Condition {
 	return Condition{
-		Type:               string(ConditionTypeReady),
-		Status:             ConditionFalse,
-		LastTransitionTime: time.Now(),
-		Reason:             string(ConditionReasonFailed),
-		Message:            msg,
+		Type:    string(ConditionTypeReady),
+		Status:  ConditionFalse,
+		Reason:  string(ConditionReasonFailed),
+		Message: msg,
 	}
 }
@@ -0,0 +1,114 @@
+// GENERATED CODE - DO NOT EDIT
+package v1
+
+import (
+	"github.com/henderiw/godantic/pkg/diff"
+)
+
+// Equal returns true if the receiver and other are equal, ignoring the fields
+// marked with +equal(skip).
+func (r Condition) Equal(other Condition) bool {
+	if r.Type != other.Type {
+		return false
+	}
+	if r.Status != other.Status {
+		return false
+	}
+	if r.ObservedGeneration != other.ObservedGeneration {
+		return false
+	}
+	if r.Reason != other.Reason {
+		return false
+	}
+	if r.Message != other.Message {
+		return false
+	}
+	return true
+}
+
+// Diff returns the changes from the receiver to other, ignoring the fields
+// marked with +equal(skip).
+func (r Condition) Diff(other Condition) diff.Changes {
+	var changes diff.Changes
+	if r.Type != other.Type {
+		changes = append(changes, diff.Change{Path: "type", Old: r.Type, New: other.Type})
+	}
+	if r.Status != other.Status {
+		changes = append(changes, diff.Change{Path: "status", Old: r.Status, New: other.Status})
+	}
+	if r.ObservedGeneration != other.ObservedGeneration {
+		changes = append(changes, diff.Change{Path: "observedGeneration", Old: r.ObservedGeneration, New: other.ObservedGeneration})
+	}
+	if r.Reason != other.Reason {
+		changes = append(changes, diff.Change{Path: "reason", Old: r.Reason, New: other.Reason})
+	}
+	if r.Message != other.Message {
+		changes = append(changes, diff.Change{Path: "message", Old: r.Message, New: other.Message})
+	}
+	return changes
+}
+
+// Equal returns true if the receiver and other are equal, ignoring the fields
+// marked with +equal(skip).
+func (r *ConditionedStatus) Equal(other *ConditionedStatus) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if len(r.Conditions) != len(other.Conditions) {
+		return false
+	}
+	for i := range r.Conditions {
+		found := false
+		for j := range other.Conditions {
+			if r.Conditions[i].Type == other.Conditions[j].Type {
+				if !r.Conditions[i].Equal(other.Conditions[j]) {
+					return false
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Diff returns the changes from the receiver to other, ignoring the fields
+// marked with +equal(skip).
+func (r *ConditionedStatus) Diff(other *ConditionedStatus) diff.Changes {
+	if r == nil || other == nil {
+		if r == other {
+			return nil
+		}
+		return diff.Changes{{Old: diff.Deref(r), New: diff.Deref(other)}}
+	}
+	var changes diff.Changes
+	for i := range r.Conditions {
+		found := false
+		for j := range other.Conditions {
+			if r.Conditions[i].Type == other.Conditions[j].Type {
+				changes = append(changes, r.Conditions[i].Diff(other.Conditions[j]).Prefix(diff.Key("conditions", r.Conditions[i].Type))...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			changes = append(changes, diff.Change{Path: diff.Key("conditions", r.Conditions[i].Type), Old: r.Conditions[i]})
+		}
+	}
+	for j := range other.Conditions {
+		found := false
+		for i := range r.Conditions {
+			if r.Conditions[i].Type == other.Conditions[j].Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			changes = append(changes, diff.Change{Path: diff.Key("conditions", other.Conditions[j].Type), New: other.Conditions[j]})
+		}
+	}
+	return changes
+}
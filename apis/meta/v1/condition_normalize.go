@@ -0,0 +1,33 @@
+// GENERATED CODE - DO NOT EDIT
+package v1
+
+import (
+	"cmp"
+	"sort"
+
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+// Normalize applies the transforms of the fields of the receiver and of its
+// nested values.
+func (r *ConditionedStatus) Normalize() {
+	r.NormalizeWith(validation.Options{})
+}
+
+// NormalizeWith normalizes the receiver like Normalize. The values of
+// recursive types are not normalized again within a cycle, nor nested deeper
+// than the maximum depth of the options.
+func (r *ConditionedStatus) NormalizeWith(opts validation.Options) {
+	if r == nil {
+		return
+	}
+	sort.SliceStable(r.Conditions, func(i, j int) bool {
+		return cmp.Compare(r.Conditions[i].Type, r.Conditions[j].Type) < 0
+	})
+}
+
+// NormalizeAndValidate normalizes the receiver and validates the result.
+func (r *ConditionedStatus) NormalizeAndValidate() error {
+	r.Normalize()
+	return r.Validate()
+}
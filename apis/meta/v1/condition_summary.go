@@ -0,0 +1,119 @@
+/*
+Copyright 2024 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A ReadyPolicy describes how the Ready condition of a resource is derived
+// from its sub-conditions.
+type ReadyPolicy struct {
+	// Required lists the condition types that must be True for the resource
+	// to be ready. A required condition that is not set is treated as Unknown.
+	Required []ConditionType
+	// NegativePolarity lists the condition types that report a problem when
+	// they are True, e.g. Degraded or Stalled. They may be absent; when set
+	// they must be False for the resource to be ready.
+	NegativePolarity []ConditionType
+}
+
+// SummarizeReady derives the Ready condition from the sub-conditions listed
+// in the policy, sets it on the status and returns it.
+//
+// Ready is False when a required condition is False or a negative polarity
+// condition is True, Unknown when none failed but a condition is unknown or a
+// required condition is not set, and True otherwise. The message names the
+// sub-conditions responsible for a Ready condition that is not True and the
+// observed generation is the oldest one of the sub-conditions taken into
+// account.
+func (r *ConditionedStatus) SummarizeReady(policy ReadyPolicy) Condition {
+	var failed, unknown []string
+	var observedGeneration int64
+	// observed is set once a sub-condition was taken into account, since
+	// zero is a valid observed generation
+	observed := false
+	observe := func(c *Condition) {
+		if !observed || c.ObservedGeneration < observedGeneration {
+			observedGeneration = c.ObservedGeneration
+			observed = true
+		}
+	}
+
+	for _, t := range policy.Required {
+		c := r.FindCondition(t)
+		if c == nil {
+			unknown = append(unknown, fmt.Sprintf("%s is not set", t))
+			continue
+		}
+		observe(c)
+		switch c.Status {
+		case ConditionTrue:
+		case ConditionFalse:
+			failed = append(failed, summarizeCondition(c))
+		default:
+			unknown = append(unknown, summarizeCondition(c))
+		}
+	}
+	for _, t := range policy.NegativePolarity {
+		c := r.FindCondition(t)
+		if c == nil {
+			continue
+		}
+		observe(c)
+		switch c.Status {
+		case ConditionFalse:
+		case ConditionTrue:
+			failed = append(failed, summarizeCondition(c))
+		default:
+			unknown = append(unknown, summarizeCondition(c))
+		}
+	}
+
+	ready := Condition{
+		Type:               string(ConditionTypeReady),
+		ObservedGeneration: observedGeneration,
+	}
+	switch {
+	case len(failed) > 0:
+		ready.Status = ConditionFalse
+		ready.Reason = string(ConditionReasonFailed)
+		ready.Message = strings.Join(append(failed, unknown...), "; ")
+	case len(unknown) > 0:
+		ready.Status = ConditionUnknown
+		ready.Reason = string(ConditionReasonUnknown)
+		ready.Message = strings.Join(unknown, "; ")
+	default:
+		ready.Status = ConditionTrue
+		ready.Reason = string(ConditionReasonReady)
+	}
+	r.SetCondition(ready)
+	return *r.FindCondition(ConditionTypeReady)
+}
+
+// summarizeCondition describes a sub-condition in the message of a summary.
+func summarizeCondition(c *Condition) string {
+	s := fmt.Sprintf("%s is %s", c.Type, c.Status)
+	if c.Reason != "" {
+		s = fmt.Sprintf("%s (%s)", s, c.Reason)
+	}
+	if c.Message != "" {
+		s = fmt.Sprintf("%s: %s", s, c.Message)
+	}
+	return s
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import "testing"
+
+func TestSummarizeReady(t *testing.T) {
+	policy := ReadyPolicy{
+		Required:         []ConditionType{"Synced", "Allocated"},
+		NegativePolarity: []ConditionType{"Degraded"},
+	}
+	cond := func(t string, status ConditionStatus, reason, msg string, generation int64) Condition {
+		return Condition{Type: t, Status: status, Reason: reason, Message: msg, ObservedGeneration: generation}
+	}
+
+	tests := map[string]struct {
+		conditions     []Condition
+		wantStatus     ConditionStatus
+		wantReason     ConditionReason
+		wantMessage    string
+		wantGeneration int64
+	}{
+		"AllRequiredTrue": {
+			conditions: []Condition{
+				cond("Synced", ConditionTrue, "", "", 2),
+				cond("Allocated", ConditionTrue, "", "", 3),
+			},
+			wantStatus:     ConditionTrue,
+			wantReason:     ConditionReasonReady,
+			wantGeneration: 2,
+		},
+		"RequiredMissing": {
+			conditions: []Condition{
+				cond("Synced", ConditionTrue, "", "", 2),
+			},
+			wantStatus:     ConditionUnknown,
+			wantReason:     ConditionReasonUnknown,
+			wantMessage:    "Allocated is not set",
+			wantGeneration: 2,
+		},
+		"RequiredUnknown": {
+			conditions: []Condition{
+				cond("Synced", ConditionTrue, "", "", 2),
+				cond("Allocated", ConditionUnknown, "Pending", "", 2),
+			},
+			wantStatus:     ConditionUnknown,
+			wantReason:     ConditionReasonUnknown,
+			wantMessage:    "Allocated is Unknown (Pending)",
+			wantGeneration: 2,
+		},
+		"FailuresBeforeUnknowns": {
+			conditions: []Condition{
+				cond("Synced", ConditionFalse, "Failed", "no target", 2),
+			},
+			wantStatus:     ConditionFalse,
+			wantReason:     ConditionReasonFailed,
+			wantMessage:    "Synced is False (Failed): no target; Allocated is not set",
+			wantGeneration: 2,
+		},
+		"NegativePolarityTrue": {
+			conditions: []Condition{
+				cond("Synced", ConditionTrue, "", "", 2),
+				cond("Allocated", ConditionTrue, "", "", 2),
+				cond("Degraded", ConditionTrue, "", "link down", 1),
+			},
+			wantStatus:     ConditionFalse,
+			wantReason:     ConditionReasonFailed,
+			wantMessage:    "Degraded is True: link down",
+			wantGeneration: 1,
+		},
+		"NegativePolarityFalse": {
+			conditions: []Condition{
+				cond("Synced", ConditionTrue, "", "", 2),
+				cond("Allocated", ConditionTrue, "", "", 2),
+				cond("Degraded", ConditionFalse, "", "", 2),
+			},
+			wantStatus:     ConditionTrue,
+			wantReason:     ConditionReasonReady,
+			wantGeneration: 2,
+		},
+		"OldestGenerationIncludingZero": {
+			conditions: []Condition{
+				cond("Synced", ConditionTrue, "", "", 0),
+				cond("Allocated", ConditionTrue, "", "", 3),
+			},
+			wantStatus:     ConditionTrue,
+			wantReason:     ConditionReasonReady,
+			wantGeneration: 0,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := &ConditionedStatus{Conditions: tc.conditions}
+			got := status.SummarizeReady(policy)
+			if got.Status != tc.wantStatus || got.Reason != string(tc.wantReason) || got.Message != tc.wantMessage {
+				t.Errorf("SummarizeReady() = %s/%s %q, want %s/%s %q", got.Status, got.Reason, got.Message, tc.wantStatus, tc.wantReason, tc.wantMessage)
+			}
+			if got.ObservedGeneration != tc.wantGeneration {
+				t.Errorf("SummarizeReady() observed generation = %d, want %d", got.ObservedGeneration, tc.wantGeneration)
+			}
+			if ready := status.FindCondition(ConditionTypeReady); ready == nil || !ready.Equal(got) {
+				t.Errorf("SummarizeReady() did not set the Ready condition %+v", got)
+			}
+		})
+	}
+}
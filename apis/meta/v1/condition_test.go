@@ -0,0 +1,134 @@
+/*
+Copyright 2024 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+	"time"
+)
+
+var (
+	t0 = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 = t0.Add(time.Hour)
+	t2 = t0.Add(2 * time.Hour)
+)
+
+func TestSetConditionWithClock(t *testing.T) {
+	clock := ClockFunc(func() time.Time { return t1 })
+	synced := func(status ConditionStatus, reason string, at time.Time) Condition {
+		return Condition{Type: "Synced", Status: status, Reason: reason, LastTransitionTime: at}
+	}
+
+	tests := map[string]struct {
+		existing    []Condition
+		set         Condition
+		wantChanged bool
+		want        Condition
+	}{
+		"NewConditionIsStamped": {
+			set:         synced(ConditionTrue, "Synced", time.Time{}),
+			wantChanged: true,
+			want:        synced(ConditionTrue, "Synced", t1),
+		},
+		"NewConditionKeepsItsTime": {
+			set:         synced(ConditionTrue, "Synced", t2),
+			wantChanged: true,
+			want:        synced(ConditionTrue, "Synced", t2),
+		},
+		"IdenticalConditionIsUnchanged": {
+			existing:    []Condition{synced(ConditionTrue, "Synced", t0)},
+			set:         synced(ConditionTrue, "Synced", time.Time{}),
+			wantChanged: false,
+			want:        synced(ConditionTrue, "Synced", t0),
+		},
+		"UnchangedStatusKeepsTransitionTime": {
+			existing:    []Condition{synced(ConditionFalse, "Pending", t0)},
+			set:         synced(ConditionFalse, "Failed", t2),
+			wantChanged: true,
+			want:        synced(ConditionFalse, "Failed", t0),
+		},
+		"StatusFlipIsStamped": {
+			existing:    []Condition{synced(ConditionFalse, "Failed", t0)},
+			set:         synced(ConditionTrue, "Synced", time.Time{}),
+			wantChanged: true,
+			want:        synced(ConditionTrue, "Synced", t1),
+		},
+		"StatusFlipKeepsSuppliedTime": {
+			existing:    []Condition{synced(ConditionFalse, "Failed", t0)},
+			set:         synced(ConditionTrue, "Synced", t2),
+			wantChanged: true,
+			want:        synced(ConditionTrue, "Synced", t2),
+		},
+		"ObservedGenerationChange": {
+			existing:    []Condition{synced(ConditionTrue, "Synced", t0)},
+			set:         synced(ConditionTrue, "Synced", time.Time{}).WithObservedGeneration(3),
+			wantChanged: true,
+			want:        synced(ConditionTrue, "Synced", t0).WithObservedGeneration(3),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := &ConditionedStatus{Conditions: tc.existing}
+			if changed := status.SetConditionWithClock(clock, tc.set); changed != tc.wantChanged {
+				t.Errorf("SetConditionWithClock() changed = %t, want %t", changed, tc.wantChanged)
+			}
+			if len(status.Conditions) != 1 {
+				t.Fatalf("SetConditionWithClock() conditions = %v, want one condition", status.Conditions)
+			}
+			got := status.Conditions[0]
+			if !got.Equal(tc.want) || !got.LastTransitionTime.Equal(tc.want.LastTransitionTime) {
+				t.Errorf("SetConditionWithClock() condition = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetConditionsForGeneration(t *testing.T) {
+	tests := map[string]struct {
+		meta           *ObjectMeta
+		wantSet        bool
+		wantGeneration int64
+		wantObserved   bool
+	}{
+		"RecordsGeneration": {
+			meta:           &ObjectMeta{Generation: 4},
+			wantSet:        true,
+			wantGeneration: 4,
+			wantObserved:   true,
+		},
+		"NilMeta": {
+			meta:    nil,
+			wantSet: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := &ConditionedStatus{}
+			status.SetConditionsForGeneration(tc.meta, Ready())
+			c := status.FindCondition(ConditionTypeReady)
+			if (c != nil) != tc.wantSet {
+				t.Fatalf("SetConditionsForGeneration() set = %t, want %t", c != nil, tc.wantSet)
+			}
+			if c != nil && c.ObservedGeneration != tc.wantGeneration {
+				t.Errorf("SetConditionsForGeneration() observed generation = %d, want %d", c.ObservedGeneration, tc.wantGeneration)
+			}
+			if got := status.IsConditionObserved(ConditionTypeReady, tc.meta); got != tc.wantObserved {
+				t.Errorf("IsConditionObserved() = %t, want %t", got, tc.wantObserved)
+			}
+		})
+	}
+}
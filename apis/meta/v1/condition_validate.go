@@ -2,48 +2,268 @@
 package v1
 
 import (
+	"context"
 	"errors"
-	"fmt"
+
+	"github.com/henderiw/godantic/pkg/validation"
 )
 
 func (r ConditionType) Validate() error {
 	valid := map[string]struct{}{"Ready": {}}
 	if _, ok := valid[string(r)]; !ok {
-		return fmt.Errorf("invalid value for ConditionType: %s", r)
+		return validation.Invalid("", validation.ErrEnum, "enum", "ConditionType must be one of \"Ready\", got \"{value}\"", validation.Args{"value": r})
 	}
 	return nil
 }
 func (r ConditionReason) Validate() error {
 	valid := map[string]struct{}{"Ready": {}, "Failed": {}, "Unknown": {}}
 	if _, ok := valid[string(r)]; !ok {
-		return fmt.Errorf("invalid value for ConditionReason: %s", r)
+		return validation.Invalid("", validation.ErrEnum, "enum", "ConditionReason must be one of \"Ready\", \"Failed\", \"Unknown\", got \"{value}\"", validation.Args{"value": r})
 	}
 	return nil
 }
 func (r ConditionStatus) Validate() error {
 	valid := map[string]struct{}{"True": {}, "False": {}, "Unknown": {}}
 	if _, ok := valid[string(r)]; !ok {
-		return fmt.Errorf("invalid value for ConditionStatus: %s", r)
+		return validation.Invalid("", validation.ErrEnum, "enum", "ConditionStatus must be one of \"True\", \"False\", \"Unknown\", got \"{value}\"", validation.Args{"value": r})
 	}
 	return nil
 }
 func (r *Condition) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *Condition) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *Condition) ValidateContext(ctx context.Context, opts validation.Options) error {
 	var errs error
 	if err := r.Status.Validate(); err != nil {
-		errs = errors.Join(errs, err)
+		errs = errors.Join(errs, validation.Prefix("status", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if err := ValidateTime(r.LastTransitionTime); err != nil {
+		errs = errors.Join(errs, validation.Prefix("lastTransitionTime", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *Condition) ValidateUpdate(old *Condition) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *Condition) ValidateUpdateContext(ctx context.Context, old *Condition, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if err := r.Status.Validate(); err != nil {
+		errs = errors.Join(errs, validation.Prefix("status", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if err := ValidateTime(r.LastTransitionTime); err != nil {
+		errs = errors.Join(errs, validation.Prefix("lastTransitionTime", err))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *Condition) ValidateRatcheting(old *Condition) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *Condition) ValidateRatchetingContext(ctx context.Context, old *Condition, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if r.Status != old.Status {
+		if err := r.Status.Validate(); err != nil {
+			errs = errors.Join(errs, validation.Prefix("status", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.LastTransitionTime, old.LastTransitionTime) {
+		if err := ValidateTime(r.LastTransitionTime); err != nil {
+			errs = errors.Join(errs, validation.Prefix("lastTransitionTime", err))
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
 	}
 	if errs != nil {
 		return errs
 	}
 	return nil
 }
+
 func (r *ConditionedStatus) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *ConditionedStatus) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *ConditionedStatus) ValidateContext(ctx context.Context, opts validation.Options) error {
+	var errs error
+	{
+		seen := make(map[any]int, len(r.Conditions))
+		for j := range r.Conditions {
+			key := r.Conditions[j].Type
+			if first, ok := seen[key]; ok {
+				errs = errors.Join(errs, validation.Invalid(validation.Index("conditions", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+				continue
+			}
+			seen[key] = j
+		}
+	}
+	for i, item := range r.Conditions {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(errs, err)
+		}
+		if err := item.ValidateContext(ctx, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix(validation.Index("conditions", i), err))
+		}
+		if opts.Exceeded(errs) {
+			return errs
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *ConditionedStatus) ValidateUpdate(old *ConditionedStatus) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *ConditionedStatus) ValidateUpdateContext(ctx context.Context, old *ConditionedStatus, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	{
+		seen := make(map[any]int, len(r.Conditions))
+		for j := range r.Conditions {
+			key := r.Conditions[j].Type
+			if first, ok := seen[key]; ok {
+				errs = errors.Join(errs, validation.Invalid(validation.Index("conditions", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+				continue
+			}
+			seen[key] = j
+		}
+	}
+	for i, item := range r.Conditions {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(errs, err)
+		}
+		oldItem := validation.ElemAt(old.Conditions, i)
+		if err := item.ValidateUpdateContext(ctx, oldItem, opts.Nested(errs)); err != nil {
+			errs = errors.Join(errs, validation.Prefix(validation.Index("conditions", i), err))
+		}
+		if opts.Exceeded(errs) {
+			return errs
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *ConditionedStatus) ValidateRatcheting(old *ConditionedStatus) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *ConditionedStatus) ValidateRatchetingContext(ctx context.Context, old *ConditionedStatus, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
 	var errs error
-	for _, item := range r.Conditions {
-		if err := item.Validate(); err != nil {
-			errs = errors.Join(errs, err)
+	if !validation.DeepEqual(r.Conditions, old.Conditions) {
+		{
+			seen := make(map[any]int, len(r.Conditions))
+			for j := range r.Conditions {
+				key := r.Conditions[j].Type
+				if first, ok := seen[key]; ok {
+					errs = errors.Join(errs, validation.Invalid(validation.Index("conditions", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+					continue
+				}
+				seen[key] = j
+			}
+		}
+		for i, item := range r.Conditions {
+			if err := ctx.Err(); err != nil {
+				return errors.Join(errs, err)
+			}
+			oldItem := validation.ElemAt(old.Conditions, validation.IndexOf(len(old.Conditions), func(j int) bool { return old.Conditions[j].Type == item.Type }))
+			if err := item.ValidateRatchetingContext(ctx, oldItem, opts.Nested(errs)); err != nil {
+				errs = errors.Join(errs, validation.Prefix(validation.Index("conditions", i), err))
+			}
+			if opts.Exceeded(errs) {
+				return errs
+			}
 		}
 	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
 	if errs != nil {
 		return errs
 	}
@@ -0,0 +1,24 @@
+// GENERATED CODE - DO NOT EDIT
+package v1
+
+import ()
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *ListMeta) DeepCopyInto(out *ListMeta) {
+	*out = *in
+	if in.RemainingItemCount != nil {
+		in, out := &in.RemainingItemCount, &out.RemainingItemCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy creates a new ListMeta by deep copying the receiver.
+func (in *ListMeta) DeepCopy() *ListMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(ListMeta)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,51 @@
+// GENERATED CODE - DO NOT EDIT
+package v1
+
+import (
+	"time"
+)
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *ManagedFieldsEntry) DeepCopyInto(out *ManagedFieldsEntry) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = new(time.Time)
+		**out = **in
+	}
+	if in.FieldsV1 != nil {
+		in, out := &in.FieldsV1, &out.FieldsV1
+		*out = new(FieldsV1)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy creates a new ManagedFieldsEntry by deep copying the receiver.
+func (in *ManagedFieldsEntry) DeepCopy() *ManagedFieldsEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedFieldsEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *FieldsV1) DeepCopyInto(out *FieldsV1) {
+	*out = *in
+	if in.Raw != nil {
+		in, out := &in.Raw, &out.Raw
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy creates a new FieldsV1 by deep copying the receiver.
+func (in *FieldsV1) DeepCopy() *FieldsV1 {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldsV1)
+	in.DeepCopyInto(out)
+	return out
+}
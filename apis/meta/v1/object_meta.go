@@ -4,6 +4,7 @@ import "time"
 
 // ObjectMeta is metadata that all persisted resources must have, which includes all objects
 // users must create.
+// +generate:validate
 type ObjectMeta struct {
 	// Name must be unique within a namespace. Is required when creating resources, although
 	// some resources may allow a client to request the generation of an appropriate name
@@ -42,6 +43,7 @@ type ObjectMeta struct {
 
 	// Deprecated: selfLink is a legacy read-only field that is no longer populated by the system.
 	// +optional
+	// +deprecated
 	SelfLink string `json:"selfLink,omitempty" protobuf:"bytes,4,opt,name=selfLink"`
 
 	// UID is the unique in time and space value for this object. It is typically generated by
@@ -139,6 +141,12 @@ type ObjectMeta struct {
 	OwnerReferences []OwnerReference `json:"ownerReferences,omitempty" patchStrategy:"merge" patchMergeKey:"uid" protobuf:"bytes,13,rep,name=ownerReferences"`
 
 
+	// Relationreferences are the relations of the object to other resources.
+	// +optional
+	// +listType=map
+	// +listMapKey=apiVersion
+	// +listMapKey=kind
+	// +listMapKey=name
 	Relationreferences []RelationReference `json:"relationReferences,omitempty"`
 	// Must be empty before the object is deleted from the registry. Each entry
 	// is an identifier for the responsible component that will remove the entry
@@ -156,6 +164,7 @@ type ObjectMeta struct {
 	// +optional
 	// +patchStrategy=merge
 	// +listType=set
+	// +transform(sort, dedupe)
 	Finalizers []string `json:"finalizers,omitempty" patchStrategy:"merge" protobuf:"bytes,14,rep,name=finalizers"`
 
 	// Tombstone: ClusterName was a legacy field that was always cleared by
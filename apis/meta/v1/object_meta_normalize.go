@@ -0,0 +1,31 @@
+// GENERATED CODE - DO NOT EDIT
+package v1
+
+import (
+	"slices"
+
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+// Normalize applies the transforms of the fields of the receiver and of its
+// nested values.
+func (r *ObjectMeta) Normalize() {
+	r.NormalizeWith(validation.Options{})
+}
+
+// NormalizeWith normalizes the receiver like Normalize. The values of
+// recursive types are not normalized again within a cycle, nor nested deeper
+// than the maximum depth of the options.
+func (r *ObjectMeta) NormalizeWith(opts validation.Options) {
+	if r == nil {
+		return
+	}
+	slices.Sort(r.Finalizers)
+	r.Finalizers = validation.Dedupe(r.Finalizers)
+}
+
+// NormalizeAndValidate normalizes the receiver and validates the result.
+func (r *ObjectMeta) NormalizeAndValidate() error {
+	r.Normalize()
+	return r.Validate()
+}
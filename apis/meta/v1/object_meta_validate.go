@@ -0,0 +1,220 @@
+// GENERATED CODE - DO NOT EDIT
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+func (r *ObjectMeta) Validate() error {
+	return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})
+}
+
+// ValidateWith validates the receiver with the rules of the requested groups
+// and the rules without groups.
+func (r *ObjectMeta) ValidateWith(opts validation.Options) error {
+	return r.ValidateContext(context.Background(), opts)
+}
+
+// ValidateContext validates the receiver like ValidateWith. It stops when ctx
+// is done or when the errors reach the limit of the options.
+func (r *ObjectMeta) ValidateContext(ctx context.Context, opts validation.Options) error {
+	var errs error
+	if opts.Warnings && !validation.IsZero(r.SelfLink) {
+		errs = errors.Join(errs, validation.Deprecated("selfLink"))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	{
+		seen := make(map[any]int, len(r.OwnerReferences))
+		for j := range r.OwnerReferences {
+			key := r.OwnerReferences[j].UID
+			if first, ok := seen[key]; ok {
+				errs = errors.Join(errs, validation.Invalid(validation.Index("ownerReferences", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+				continue
+			}
+			seen[key] = j
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	{
+		seen := make(map[any]int, len(r.Relationreferences))
+		for j := range r.Relationreferences {
+			key := [3]any{r.Relationreferences[j].APIVersion, r.Relationreferences[j].Kind, r.Relationreferences[j].Name}
+			if first, ok := seen[key]; ok {
+				errs = errors.Join(errs, validation.Invalid(validation.Index("relationReferences", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+				continue
+			}
+			seen[key] = j
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	{
+		seen := make(map[any]int, len(r.Finalizers))
+		for j := range r.Finalizers {
+			key := r.Finalizers[j]
+			if first, ok := seen[key]; ok {
+				errs = errors.Join(errs, validation.Invalid(validation.Index("finalizers", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+				continue
+			}
+			seen[key] = j
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateUpdate validates the receiver as an update of old. In addition to
+// the rules of Validate it reports changes of immutable fields.
+func (r *ObjectMeta) ValidateUpdate(old *ObjectMeta) error {
+	return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateUpdateContext validates the receiver as an update of old like
+// ValidateUpdate with the rules of the requested groups and the rules without
+// groups. It stops like ValidateContext.
+func (r *ObjectMeta) ValidateUpdateContext(ctx context.Context, old *ObjectMeta, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if opts.Warnings && !validation.IsZero(r.SelfLink) {
+		errs = errors.Join(errs, validation.Deprecated("selfLink"))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	{
+		seen := make(map[any]int, len(r.OwnerReferences))
+		for j := range r.OwnerReferences {
+			key := r.OwnerReferences[j].UID
+			if first, ok := seen[key]; ok {
+				errs = errors.Join(errs, validation.Invalid(validation.Index("ownerReferences", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+				continue
+			}
+			seen[key] = j
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	{
+		seen := make(map[any]int, len(r.Relationreferences))
+		for j := range r.Relationreferences {
+			key := [3]any{r.Relationreferences[j].APIVersion, r.Relationreferences[j].Kind, r.Relationreferences[j].Name}
+			if first, ok := seen[key]; ok {
+				errs = errors.Join(errs, validation.Invalid(validation.Index("relationReferences", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+				continue
+			}
+			seen[key] = j
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	{
+		seen := make(map[any]int, len(r.Finalizers))
+		for j := range r.Finalizers {
+			key := r.Finalizers[j]
+			if first, ok := seen[key]; ok {
+				errs = errors.Join(errs, validation.Invalid(validation.Index("finalizers", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+				continue
+			}
+			seen[key] = j
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// ValidateRatcheting validates the receiver as an update of old like
+// ValidateUpdate, but ignores the failures of values unchanged from old, so
+// objects stored before a rule was tightened can still be updated.
+func (r *ObjectMeta) ValidateRatcheting(old *ObjectMeta) error {
+	return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})
+}
+
+// ValidateRatchetingContext validates the receiver like ValidateRatcheting with
+// the rules of the requested groups and the rules without groups. It stops
+// like ValidateContext.
+func (r *ObjectMeta) ValidateRatchetingContext(ctx context.Context, old *ObjectMeta, opts validation.Options) error {
+	if old == nil {
+		return r.ValidateContext(ctx, opts)
+	}
+	var errs error
+	if opts.Warnings && !validation.IsZero(r.SelfLink) {
+		errs = errors.Join(errs, validation.Deprecated("selfLink"))
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.OwnerReferences, old.OwnerReferences) {
+		{
+			seen := make(map[any]int, len(r.OwnerReferences))
+			for j := range r.OwnerReferences {
+				key := r.OwnerReferences[j].UID
+				if first, ok := seen[key]; ok {
+					errs = errors.Join(errs, validation.Invalid(validation.Index("ownerReferences", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+					continue
+				}
+				seen[key] = j
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.Relationreferences, old.Relationreferences) {
+		{
+			seen := make(map[any]int, len(r.Relationreferences))
+			for j := range r.Relationreferences {
+				key := [3]any{r.Relationreferences[j].APIVersion, r.Relationreferences[j].Kind, r.Relationreferences[j].Name}
+				if first, ok := seen[key]; ok {
+					errs = errors.Join(errs, validation.Invalid(validation.Index("relationReferences", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+					continue
+				}
+				seen[key] = j
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if !validation.DeepEqual(r.Finalizers, old.Finalizers) {
+		{
+			seen := make(map[any]int, len(r.Finalizers))
+			for j := range r.Finalizers {
+				key := r.Finalizers[j]
+				if first, ok := seen[key]; ok {
+					errs = errors.Join(errs, validation.Invalid(validation.Index("finalizers", j), validation.ErrUnique, "unique.duplicate", "duplicate of element {index}", validation.Args{"index": first}))
+					continue
+				}
+				seen[key] = j
+			}
+		}
+	}
+	if opts.Exceeded(errs) {
+		return errs
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package v1
 
 // +generate:validate
+// +generate:equal
 type ObjectReference struct {
 	// API version of the referent.
 	APIVersion string `json:"apiVersion" protobuf:"bytes,5,opt,name=apiVersion"`
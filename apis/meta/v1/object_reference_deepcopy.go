@@ -0,0 +1,19 @@
+// GENERATED CODE - DO NOT EDIT
+package v1
+
+import ()
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+	*out = *in
+}
+
+// DeepCopy creates a new ObjectReference by deep copying the receiver.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,52 @@
+// GENERATED CODE - DO NOT EDIT
+package v1
+
+import (
+	"github.com/henderiw/godantic/pkg/diff"
+)
+
+// Equal returns true if the receiver and other are equal, ignoring the fields
+// marked with +equal(skip).
+func (r *ObjectReference) Equal(other *ObjectReference) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if r.APIVersion != other.APIVersion {
+		return false
+	}
+	if r.Kind != other.Kind {
+		return false
+	}
+	if r.Name != other.Name {
+		return false
+	}
+	if r.UID != other.UID {
+		return false
+	}
+	return true
+}
+
+// Diff returns the changes from the receiver to other, ignoring the fields
+// marked with +equal(skip).
+func (r *ObjectReference) Diff(other *ObjectReference) diff.Changes {
+	if r == nil || other == nil {
+		if r == other {
+			return nil
+		}
+		return diff.Changes{{Old: diff.Deref(r), New: diff.Deref(other)}}
+	}
+	var changes diff.Changes
+	if r.APIVersion != other.APIVersion {
+		changes = append(changes, diff.Change{Path: "apiVersion", Old: r.APIVersion, New: other.APIVersion})
+	}
+	if r.Kind != other.Kind {
+		changes = append(changes, diff.Change{Path: "kind", Old: r.Kind, New: other.Kind})
+	}
+	if r.Name != other.Name {
+		changes = append(changes, diff.Change{Path: "name", Old: r.Name, New: other.Name})
+	}
+	if r.UID != other.UID {
+		changes = append(changes, diff.Change{Path: "uid", Old: r.UID, New: other.UID})
+	}
+	return changes
+}
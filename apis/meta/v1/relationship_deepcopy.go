@@ -0,0 +1,41 @@
+// GENERATED CODE - DO NOT EDIT
+package v1
+
+import ()
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *RelationReference) DeepCopyInto(out *RelationReference) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy creates a new RelationReference by deep copying the receiver.
+func (in *RelationReference) DeepCopy() *RelationReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RelationReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *RelationshipReference) DeepCopyInto(out *RelationshipReference) {
+	*out = *in
+}
+
+// DeepCopy creates a new RelationshipReference by deep copying the receiver.
+func (in *RelationshipReference) DeepCopy() *RelationshipReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RelationshipReference)
+	in.DeepCopyInto(out)
+	return out
+}
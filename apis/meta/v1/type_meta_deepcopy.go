@@ -0,0 +1,19 @@
+// GENERATED CODE - DO NOT EDIT
+package v1
+
+import ()
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *TypeMeta) DeepCopyInto(out *TypeMeta) {
+	*out = *in
+}
+
+// DeepCopy creates a new TypeMeta by deep copying the receiver.
+func (in *TypeMeta) DeepCopy() *TypeMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(TypeMeta)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2024 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/henderiw/godantic/pkg/validation"
+)
+
+// ValidateTime validates a timestamp of an object. The year must be in
+// [0, 9999] for the timestamp to be encoded in RFC 3339 format.
+//
+// +godantic:external(type=time.Time)
+func ValidateTime(t time.Time) error {
+	if year := t.Year(); year < 0 || year > 9999 {
+		return validation.Invalid("", validation.ErrRange, "range.year", "year must be in [0, 9999], got {value}", validation.Args{"value": year})
+	}
+	return nil
+}
@@ -1,4 +1,4 @@
-//go:generate go run pkg/genvalidate/generator.go ./apis
+//go:generate go run ./pkg/genvalidate ./apis
 
 package main
 
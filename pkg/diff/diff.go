@@ -0,0 +1,125 @@
+// Package diff provides the types and helpers used by the generated Diff
+// methods.
+package diff
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// A Change records a value that differs between two objects. Path is the
+// JSON path of the value, Old the value of the receiver of Diff and New the
+// value of the object it is compared with. A nil Old or New value indicates
+// the value is not set.
+type Change struct {
+	Path string `json:"path"`
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+func (r Change) String() string {
+	return fmt.Sprintf("%s: %v -> %v", r.Path, r.Old, r.New)
+}
+
+// Changes is the list of changes between two objects.
+type Changes []Change
+
+// Prefix returns the changes with the given path prepended to their paths.
+func (r Changes) Prefix(path string) Changes {
+	for i := range r {
+		r[i].Path = Join(path, r[i].Path)
+	}
+	return r
+}
+
+// Paths returns the paths of the changes.
+func (r Changes) Paths() []string {
+	paths := make([]string, 0, len(r))
+	for _, c := range r {
+		paths = append(paths, c.Path)
+	}
+	return paths
+}
+
+func (r Changes) String() string {
+	s := make([]string, 0, len(r))
+	for _, c := range r {
+		s = append(s, c.String())
+	}
+	return strings.Join(s, "\n")
+}
+
+// Join appends the child path to the parent path.
+func Join(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	case strings.HasPrefix(child, "["):
+		return parent + child
+	}
+	return parent + "." + child
+}
+
+// Index returns the path of the element of a list with the given index.
+func Index(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// Key returns the path of the element of a map, or of a list of type map,
+// with the given key values.
+func Key(path string, keys ...any) string {
+	s := make([]string, 0, len(keys))
+	for _, k := range keys {
+		s = append(s, fmt.Sprint(k))
+	}
+	return fmt.Sprintf("%s[%s]", path, strings.Join(s, ","))
+}
+
+// Deref returns the value p points to, or nil if p is nil.
+func Deref[T any](p *T) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+// PointerEqual returns true if both pointers are nil or point to equal values.
+func PointerEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Map returns the changes of the values of two maps, in the order of their
+// keys.
+func Map[M ~map[K]V, K cmp.Ordered, V comparable](path string, old, new M) Changes {
+	var changes Changes
+	keys := make([]K, 0, len(old))
+	for k := range old {
+		keys = append(keys, k)
+	}
+	for k := range new {
+		if _, ok := old[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		o, inOld := old[k]
+		n, inNew := new[k]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Path: Key(path, k), New: n})
+		case !inNew:
+			changes = append(changes, Change{Path: Key(path, k), Old: o})
+		case o != n:
+			changes = append(changes, Change{Path: Key(path, k), Old: o, New: n})
+		}
+	}
+	return changes
+}
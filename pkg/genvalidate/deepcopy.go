@@ -0,0 +1,313 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"os"
+	"strings"
+)
+
+const deepCopyMarker = "// +k8s:deepcopy-gen"
+
+// A DeepCopyGenerator generates DeepCopy and DeepCopyInto methods for the
+// types marked with +k8s:deepcopy-gen and for every type of the tree they
+// refer to.
+type DeepCopyGenerator struct {
+	loader *Loader
+	// deep records per type if a shallow copy shares memory with the original.
+	deep map[*TypeDecl]bool
+}
+
+func NewDeepCopyGenerator(loader *Loader) *DeepCopyGenerator {
+	return &DeepCopyGenerator{
+		loader: loader,
+		deep:   map[*TypeDecl]bool{},
+	}
+}
+
+func (r *DeepCopyGenerator) Generate() {
+	// collect the marked types and the types of the tree they refer to
+	selected := map[*TypeDecl]bool{}
+	var visit func(decl *TypeDecl)
+	visit = func(decl *TypeDecl) {
+		if selected[decl] {
+			return
+		}
+		selected[decl] = true
+		ast.Inspect(decl.Spec.Type, func(n ast.Node) bool {
+			expr, ok := n.(ast.Expr)
+			if !ok {
+				return true
+			}
+			if ref := r.loader.Resolve(decl.File, expr); ref != nil {
+				visit(ref)
+				return false
+			}
+			return true
+		})
+	}
+	for _, file := range r.loader.Files() {
+		for _, decl := range r.loader.FileTypes(file) {
+			if isDeepCopyMarked(decl) {
+				visit(decl)
+			}
+		}
+	}
+
+	for _, file := range r.loader.Files() {
+		var decls []*TypeDecl
+		for _, decl := range r.loader.FileTypes(file) {
+			if !selected[decl] {
+				continue
+			}
+			if _, ok := decl.Spec.Type.(*ast.StructType); !ok {
+				if r.needsDeepCopy(decl.File, decl.Spec.Type) {
+					fmt.Printf("Skipping deepcopy of %s: only struct types are supported\n", decl.Name)
+				}
+				continue
+			}
+			decls = append(decls, decl)
+		}
+		if len(decls) > 0 {
+			r.generateDeepCopyCode(file, decls)
+		}
+	}
+}
+
+func (r *DeepCopyGenerator) generateDeepCopyCode(file *File, decls []*TypeDecl) {
+	outputFile := strings.TrimSuffix(file.Path, ".go") + "_deepcopy.go"
+	imports := map[string]bool{}
+
+	var body strings.Builder
+	for _, decl := range decls {
+		name := decl.Name
+		body.WriteString("// DeepCopyInto copies the receiver into out. in must be non-nil.\n")
+		body.WriteString(fmt.Sprintf("func (in *%s) DeepCopyInto(out *%s) {\n", name, name))
+		body.WriteString("\t*out = *in\n")
+		for _, field := range decl.Spec.Type.(*ast.StructType).Fields.List {
+			if !r.needsDeepCopy(file, field.Type) {
+				continue
+			}
+			for _, fieldName := range fieldNames(field) {
+				body.WriteString(r.generateCopy(file, field.Type, "in."+fieldName, "out."+fieldName, imports))
+			}
+		}
+		body.WriteString("}\n\n")
+
+		body.WriteString(fmt.Sprintf("// DeepCopy creates a new %s by deep copying the receiver.\n", name))
+		body.WriteString(fmt.Sprintf("func (in *%s) DeepCopy() *%s {\n", name, name))
+		body.WriteString("\tif in == nil {\n\t\treturn nil\n\t}\n")
+		body.WriteString(fmt.Sprintf("\tout := new(%s)\n", name))
+		body.WriteString("\tin.DeepCopyInto(out)\n")
+		body.WriteString("\treturn out\n")
+		body.WriteString("}\n\n")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(generatedHeader + "\n")
+	sb.WriteString(fmt.Sprintf("package %s\n\n", file.Package))
+	importPaths := map[string]string{}
+	for name := range imports {
+		importPath := file.Imports[name]
+		if importPath[strings.LastIndex(importPath, "/")+1:] == name {
+			importPaths[importPath] = ""
+		} else {
+			importPaths[importPath] = name
+		}
+	}
+	writeImports(&sb, importPaths)
+	sb.WriteString(body.String())
+
+	if err := os.WriteFile(outputFile, []byte(sb.String()), 0644); err != nil {
+		fmt.Println("Error writing deepcopy file:", err)
+		return
+	}
+	formatGoFile(outputFile)
+	fmt.Println("Generated deepcopy file:", outputFile)
+}
+
+// generateCopy generates the statements that make out a deep copy of in, given
+// out already holds a shallow copy of in. in and out must be addressable
+// expressions of type expr.
+func (r *DeepCopyGenerator) generateCopy(file *File, expr ast.Expr, in, out string, imports map[string]bool) string {
+	var sb strings.Builder
+
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		sb.WriteString(fmt.Sprintf("if %s != nil {\n", in))
+		sb.WriteString(fmt.Sprintf("in, out := &%s, &%s\n", in, out))
+		sb.WriteString(fmt.Sprintf("*out = new(%s)\n", r.typeString(file, t.X, imports)))
+		if r.loader.Resolve(file, t.X) != nil && r.needsDeepCopy(file, t.X) {
+			sb.WriteString("(*in).DeepCopyInto(*out)\n")
+		} else {
+			sb.WriteString(r.generateValueCopy(file, t.X, "**in", "**out", imports))
+		}
+		sb.WriteString("}\n")
+
+	case *ast.ArrayType:
+		if t.Len != nil {
+			// arrays are values; only their elements may need a deep copy
+			sb.WriteString(fmt.Sprintf("for i := range %s {\n", in))
+			sb.WriteString(r.generateCopy(file, t.Elt, fmt.Sprintf("%s[i]", in), fmt.Sprintf("%s[i]", out), imports))
+			sb.WriteString("}\n")
+			break
+		}
+		sb.WriteString(fmt.Sprintf("if %s != nil {\n", in))
+		sb.WriteString(fmt.Sprintf("in, out := &%s, &%s\n", in, out))
+		sb.WriteString(fmt.Sprintf("*out = make(%s, len(*in))\n", r.typeString(file, t, imports)))
+		if r.needsDeepCopy(file, t.Elt) {
+			sb.WriteString("for i := range *in {\n")
+			sb.WriteString(r.generateValueCopy(file, t.Elt, "(*in)[i]", "(*out)[i]", imports))
+			sb.WriteString("}\n")
+		} else {
+			sb.WriteString("copy(*out, *in)\n")
+		}
+		sb.WriteString("}\n")
+
+	case *ast.MapType:
+		sb.WriteString(fmt.Sprintf("if %s != nil {\n", in))
+		sb.WriteString(fmt.Sprintf("in, out := &%s, &%s\n", in, out))
+		sb.WriteString(fmt.Sprintf("*out = make(%s, len(*in))\n", r.typeString(file, t, imports)))
+		sb.WriteString("for key, val := range *in {\n")
+		if r.needsDeepCopy(file, t.Value) {
+			sb.WriteString(fmt.Sprintf("var outVal %s\n", r.typeString(file, t.Value, imports)))
+			sb.WriteString(r.generateValueCopy(file, t.Value, "val", "outVal", imports))
+			sb.WriteString("(*out)[key] = outVal\n")
+		} else {
+			sb.WriteString("(*out)[key] = val\n")
+		}
+		sb.WriteString("}\n")
+		sb.WriteString("}\n")
+
+	case *ast.StructType:
+		// anonymous structs have no methods; copy their fields in place
+		for _, field := range t.Fields.List {
+			if !r.needsDeepCopy(file, field.Type) {
+				continue
+			}
+			for _, fieldName := range fieldNames(field) {
+				sb.WriteString(r.generateCopy(file, field.Type, in+"."+fieldName, out+"."+fieldName, imports))
+			}
+		}
+
+	default:
+		if r.needsDeepCopy(file, expr) {
+			sb.WriteString(fmt.Sprintf("%s.DeepCopyInto(&%s)\n", in, out))
+		}
+	}
+	return sb.String()
+}
+
+// generateValueCopy generates the statements that make out a deep copy of in
+// when out does not hold a copy of in yet.
+func (r *DeepCopyGenerator) generateValueCopy(file *File, expr ast.Expr, in, out string, imports map[string]bool) string {
+	if !r.needsDeepCopy(file, expr) {
+		return fmt.Sprintf("%s = %s\n", out, in)
+	}
+	switch t := expr.(type) {
+	case *ast.StarExpr, *ast.MapType:
+		return r.generateCopy(file, expr, in, out, imports)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return r.generateCopy(file, expr, in, out, imports)
+		}
+		return fmt.Sprintf("%s = %s\n", out, in) + r.generateCopy(file, expr, in, out, imports)
+	case *ast.StructType:
+		return fmt.Sprintf("%s = %s\n", out, in) + r.generateCopy(file, expr, in, out, imports)
+	}
+	return fmt.Sprintf("%s.DeepCopyInto(&%s)\n", in, out)
+}
+
+// needsDeepCopy returns true if a shallow copy of a value of type expr shares
+// memory with the original value.
+func (r *DeepCopyGenerator) needsDeepCopy(file *File, expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.StarExpr, *ast.MapType:
+		return true
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return true
+		}
+		return r.needsDeepCopy(file, t.Elt)
+	case *ast.StructType:
+		for _, field := range t.Fields.List {
+			if r.needsDeepCopy(file, field.Type) {
+				return true
+			}
+		}
+		return false
+	case *ast.Ident, *ast.SelectorExpr:
+		decl := r.loader.Resolve(file, expr)
+		if decl == nil {
+			// predeclared types and types of other modules, e.g. time.Time,
+			// are copied by value
+			return false
+		}
+		deep, ok := r.deep[decl]
+		if !ok {
+			// guard against recursive types while the result is computed
+			r.deep[decl] = false
+			deep = r.needsDeepCopy(decl.File, decl.Spec.Type)
+			r.deep[decl] = deep
+		}
+		return deep
+	}
+	return false
+}
+
+// typeString returns the source representation of the type expression and
+// records the packages it refers to.
+func (r *DeepCopyGenerator) typeString(file *File, expr ast.Expr, imports map[string]bool) string {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok {
+				if _, ok := file.Imports[pkg.Name]; ok {
+					imports[pkg.Name] = true
+				}
+			}
+		}
+		return true
+	})
+	return types.ExprString(expr)
+}
+
+func isDeepCopyMarked(decl *TypeDecl) bool {
+	doc := decl.Doc()
+	if doc == nil {
+		return false
+	}
+	for _, comment := range doc.List {
+		text := strings.TrimSpace(comment.Text)
+		if strings.HasPrefix(text, deepCopyMarker) && text != deepCopyMarker+"=false" {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldNames returns the names of the struct field; an embedded field is
+// named after its type.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		return []string{embeddedFieldName(field.Type)}
+	}
+	names := make([]string, 0, len(field.Names))
+	for _, ident := range field.Names {
+		names = append(names, ident.Name)
+	}
+	return names
+}
+
+// embeddedFieldName returns the name of an embedded field of the given type.
+func embeddedFieldName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedFieldName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"os"
+	"strings"
+)
+
+const (
+	equalMarker     = "// +generate:equal"
+	equalSkipMarker = "// +equal(skip)"
+	// equalValueMarker generates Equal and Diff methods with value receivers
+	// and parameters, e.g. `func (r T) Equal(other T) bool`, for the types
+	// compared by value.
+	equalValueMarker = "// +equal(value)"
+	diffImportPath   = "github.com/henderiw/godantic/pkg/diff"
+)
+
+// An EqualGenerator generates Equal and Diff methods for the struct types
+// marked with +generate:equal.
+type EqualGenerator struct {
+	loader *Loader
+}
+
+func NewEqualGenerator(loader *Loader) *EqualGenerator {
+	return &EqualGenerator{
+		loader: loader,
+	}
+}
+
+func (r *EqualGenerator) Generate() {
+	for _, file := range r.loader.Files() {
+		var decls []*TypeDecl
+		for _, decl := range r.loader.FileTypes(file) {
+			if _, ok := decl.Spec.Type.(*ast.StructType); ok && decl.HasMarker(equalMarker) {
+				decls = append(decls, decl)
+			}
+		}
+		if len(decls) > 0 {
+			r.generateEqualCode(file, decls)
+		}
+	}
+}
+
+func (r *EqualGenerator) generateEqualCode(file *File, decls []*TypeDecl) {
+	outputFile := strings.TrimSuffix(file.Path, ".go") + "_equal.go"
+	imports := map[string]bool{diffImportPath: true}
+
+	var body strings.Builder
+	for _, decl := range decls {
+		fields := decl.Spec.Type.(*ast.StructType).Fields.List
+		byValue := decl.HasMarker(equalValueMarker)
+
+		body.WriteString("// Equal returns true if the receiver and other are equal, ignoring the fields\n")
+		body.WriteString("// marked with +equal(skip).\n")
+		if byValue {
+			body.WriteString(fmt.Sprintf("func (r %s) Equal(other %s) bool {\n", decl.Name, decl.Name))
+		} else {
+			body.WriteString(fmt.Sprintf("func (r *%s) Equal(other *%s) bool {\n", decl.Name, decl.Name))
+			body.WriteString("if r == nil || other == nil {\nreturn r == other\n}\n")
+		}
+		for _, field := range fields {
+			if hasFieldMarker(field, equalSkipMarker) {
+				continue
+			}
+			for _, name := range fieldNames(field) {
+				body.WriteString(r.generateFieldEqual(file, field, "r."+name, "other."+name, imports))
+			}
+		}
+		body.WriteString("return true\n")
+		body.WriteString("}\n\n")
+
+		body.WriteString("// Diff returns the changes from the receiver to other, ignoring the fields\n")
+		body.WriteString("// marked with +equal(skip).\n")
+		if byValue {
+			body.WriteString(fmt.Sprintf("func (r %s) Diff(other %s) diff.Changes {\n", decl.Name, decl.Name))
+		} else {
+			body.WriteString(fmt.Sprintf("func (r *%s) Diff(other *%s) diff.Changes {\n", decl.Name, decl.Name))
+			body.WriteString("if r == nil || other == nil {\nif r == other {\nreturn nil\n}\n")
+			body.WriteString("return diff.Changes{{Old: diff.Deref(r), New: diff.Deref(other)}}\n}\n")
+		}
+		body.WriteString("var changes diff.Changes\n")
+		for _, field := range fields {
+			if hasFieldMarker(field, equalSkipMarker) {
+				continue
+			}
+			path, inline := jsonName(field)
+			if inline {
+				path = ""
+			}
+			for _, name := range fieldNames(field) {
+				body.WriteString(r.generateFieldDiff(file, field, "r."+name, "other."+name, path, imports))
+			}
+		}
+		body.WriteString("return changes\n")
+		body.WriteString("}\n\n")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(generatedHeader + "\n")
+	sb.WriteString(fmt.Sprintf("package %s\n\n", file.Package))
+	importPaths := map[string]string{}
+	for importPath := range imports {
+		importPaths[importPath] = ""
+	}
+	writeImports(&sb, importPaths)
+	sb.WriteString(body.String())
+
+	if err := os.WriteFile(outputFile, []byte(sb.String()), 0644); err != nil {
+		fmt.Println("Error writing equal file:", err)
+		return
+	}
+	formatGoFile(outputFile)
+	fmt.Println("Generated equal file:", outputFile)
+}
+
+// generateFieldEqual generates the statements returning false when the field
+// values a and b differ.
+func (r *EqualGenerator) generateFieldEqual(file *File, field *ast.Field, a, b string, imports map[string]bool) string {
+	switch t := field.Type.(type) {
+	case *ast.StarExpr:
+		switch {
+		case r.loader.isScalar(file, t.X):
+			return fmt.Sprintf("if !diff.PointerEqual(%s, %s) {\nreturn false\n}\n", a, b)
+		case r.loader.isValueEqualer(file, t.X):
+			return fmt.Sprintf("if (%s == nil) != (%s == nil) || %s != nil && !%s.Equal(*%s) {\nreturn false\n}\n", a, b, a, a, b)
+		case r.loader.isEqualer(file, t.X):
+			return fmt.Sprintf("if !%s.Equal(%s) {\nreturn false\n}\n", a, b)
+		}
+	case *ast.ArrayType:
+		if t.Len != nil {
+			break
+		}
+		elt, ref := t.Elt, "&"
+		star, pointer := elt.(*ast.StarExpr)
+		if pointer {
+			elt, ref = star.X, ""
+		}
+		if r.loader.isValueEqualer(file, elt) {
+			if pointer {
+				break
+			}
+			ref = ""
+		}
+		switch {
+		case !pointer && r.loader.isScalar(file, elt):
+			imports["slices"] = true
+			return fmt.Sprintf("if !slices.Equal(%s, %s) {\nreturn false\n}\n", a, b)
+		case r.loader.isEqualer(file, elt):
+			var sb strings.Builder
+			sb.WriteString(fmt.Sprintf("if len(%s) != len(%s) {\nreturn false\n}\n", a, b))
+			if match, ok := r.listMapMatch(file, field, elt, a+"[i]", b+"[j]"); ok {
+				sb.WriteString(fmt.Sprintf("for i := range %s {\n", a))
+				sb.WriteString("found := false\n")
+				sb.WriteString(fmt.Sprintf("for j := range %s {\n", b))
+				sb.WriteString(fmt.Sprintf("if %s {\n", match))
+				sb.WriteString(fmt.Sprintf("if !%s[i].Equal(%s%s[j]) {\nreturn false\n}\n", a, ref, b))
+				sb.WriteString("found = true\nbreak\n}\n}\n")
+				sb.WriteString("if !found {\nreturn false\n}\n")
+				sb.WriteString("}\n")
+				return sb.String()
+			}
+			sb.WriteString(fmt.Sprintf("for i := range %s {\n", a))
+			sb.WriteString(fmt.Sprintf("if !%s[i].Equal(%s%s[i]) {\nreturn false\n}\n", a, ref, b))
+			sb.WriteString("}\n")
+			return sb.String()
+		}
+	case *ast.MapType:
+		if r.loader.isScalar(file, t.Key) && r.loader.isScalar(file, t.Value) {
+			imports["maps"] = true
+			return fmt.Sprintf("if !maps.Equal(%s, %s) {\nreturn false\n}\n", a, b)
+		}
+	default:
+		switch {
+		case r.loader.isScalar(file, t):
+			return fmt.Sprintf("if %s != %s {\nreturn false\n}\n", a, b)
+		case r.loader.isValueEqualer(file, t):
+			return fmt.Sprintf("if !%s.Equal(%s) {\nreturn false\n}\n", a, b)
+		case r.loader.isEqualer(file, t):
+			return fmt.Sprintf("if !%s.Equal(&%s) {\nreturn false\n}\n", a, b)
+		}
+	}
+	imports["reflect"] = true
+	return fmt.Sprintf("if !reflect.DeepEqual(%s, %s) {\nreturn false\n}\n", a, b)
+}
+
+// generateFieldDiff generates the statements recording the changes from the
+// field value a to the field value b.
+func (r *EqualGenerator) generateFieldDiff(file *File, field *ast.Field, a, b, path string, imports map[string]bool) string {
+	// a value of an inlined field changes as a whole under the path of the
+	// struct, and a pointer changes by the value it points to
+	change := fmt.Sprintf("changes = append(changes, diff.Change{Path: %q, Old: %s, New: %s})\n", path, a, b)
+	if _, ok := field.Type.(*ast.StarExpr); ok {
+		change = fmt.Sprintf("changes = append(changes, diff.Change{Path: %q, Old: diff.Deref(%s), New: diff.Deref(%s)})\n", path, a, b)
+	}
+
+	switch t := field.Type.(type) {
+	case *ast.StarExpr:
+		switch {
+		case r.loader.isScalar(file, t.X):
+			return fmt.Sprintf("if !diff.PointerEqual(%s, %s) {\n%s}\n", a, b, change)
+		case r.loader.isValueEqualer(file, t.X):
+			return fmt.Sprintf("if %s == nil || %s == nil {\nif %s != %s {\n%s}\n} else {\nchanges = append(changes, %s.Diff(*%s).Prefix(%q)...)\n}\n", a, b, a, b, change, a, b, path)
+		case r.loader.isEqualer(file, t.X):
+			return fmt.Sprintf("changes = append(changes, %s.Diff(%s).Prefix(%q)...)\n", a, b, path)
+		}
+	case *ast.ArrayType:
+		if t.Len != nil {
+			break
+		}
+		elt, ref := t.Elt, "&"
+		star, pointer := elt.(*ast.StarExpr)
+		if pointer {
+			elt, ref = star.X, ""
+		}
+		if r.loader.isValueEqualer(file, elt) {
+			if pointer {
+				break
+			}
+			ref = ""
+		}
+		switch {
+		case !pointer && r.loader.isScalar(file, elt):
+			imports["slices"] = true
+			return fmt.Sprintf("if !slices.Equal(%s, %s) {\n%s}\n", a, b, change)
+		case r.loader.isEqualer(file, elt):
+			var sb strings.Builder
+			if match, ok := r.listMapMatch(file, field, elt, a+"[i]", b+"[j]"); ok {
+				keyA, _ := r.listMapKey(file, field, elt, a+"[i]")
+				keyB, _ := r.listMapKey(file, field, elt, b+"[j]")
+				sb.WriteString(fmt.Sprintf("for i := range %s {\n", a))
+				sb.WriteString("found := false\n")
+				sb.WriteString(fmt.Sprintf("for j := range %s {\n", b))
+				sb.WriteString(fmt.Sprintf("if %s {\n", match))
+				sb.WriteString(fmt.Sprintf("changes = append(changes, %s[i].Diff(%s%s[j]).Prefix(diff.Key(%q, %s))...)\n", a, ref, b, path, keyA))
+				sb.WriteString("found = true\nbreak\n}\n}\n")
+				sb.WriteString("if !found {\n")
+				sb.WriteString(fmt.Sprintf("changes = append(changes, diff.Change{Path: diff.Key(%q, %s), Old: %s[i]})\n", path, keyA, a))
+				sb.WriteString("}\n}\n")
+				sb.WriteString(fmt.Sprintf("for j := range %s {\n", b))
+				sb.WriteString("found := false\n")
+				sb.WriteString(fmt.Sprintf("for i := range %s {\n", a))
+				sb.WriteString(fmt.Sprintf("if %s {\n", match))
+				sb.WriteString("found = true\nbreak\n}\n}\n")
+				sb.WriteString("if !found {\n")
+				sb.WriteString(fmt.Sprintf("changes = append(changes, diff.Change{Path: diff.Key(%q, %s), New: %s[j]})\n", path, keyB, b))
+				sb.WriteString("}\n}\n")
+				return sb.String()
+			}
+			sb.WriteString(fmt.Sprintf("if len(%s) != len(%s) {\n%s} else {\n", a, b, change))
+			sb.WriteString(fmt.Sprintf("for i := range %s {\n", a))
+			sb.WriteString(fmt.Sprintf("changes = append(changes, %s[i].Diff(%s%s[i]).Prefix(diff.Index(%q, i))...)\n", a, ref, b, path))
+			sb.WriteString("}\n}\n")
+			return sb.String()
+		}
+	case *ast.MapType:
+		if r.loader.isOrdered(file, t.Key) && r.loader.isScalar(file, t.Value) {
+			return fmt.Sprintf("changes = append(changes, diff.Map(%q, %s, %s)...)\n", path, a, b)
+		}
+	default:
+		switch {
+		case r.loader.isScalar(file, t):
+			return fmt.Sprintf("if %s != %s {\n%s}\n", a, b, change)
+		case r.loader.isValueEqualer(file, t):
+			return fmt.Sprintf("changes = append(changes, %s.Diff(%s).Prefix(%q)...)\n", a, b, path)
+		case r.loader.isEqualer(file, t):
+			return fmt.Sprintf("changes = append(changes, %s.Diff(&%s).Prefix(%q)...)\n", a, b, path)
+		}
+	}
+	imports["reflect"] = true
+	return fmt.Sprintf("if !reflect.DeepEqual(%s, %s) {\n%s}\n", a, b, change)
+}
+
+// listMapMatch returns the expression comparing the keys of the elements a
+// and b of a list of type map.
+func (r *EqualGenerator) listMapMatch(file *File, field *ast.Field, elt ast.Expr, a, b string) (string, bool) {
+	keys, ok := r.loader.listMapKeyFields(file, field, elt)
+	if !ok {
+		return "", false
+	}
+	conds := make([]string, 0, len(keys))
+	for _, key := range keys {
+		conds = append(conds, fmt.Sprintf("%s.%s == %s.%s", a, key, b, key))
+	}
+	return strings.Join(conds, " && "), true
+}
+
+// listMapKey returns the arguments of diff.Key identifying the element a of a
+// list of type map.
+func (r *EqualGenerator) listMapKey(file *File, field *ast.Field, elt ast.Expr, a string) (string, bool) {
+	keys, ok := r.loader.listMapKeyFields(file, field, elt)
+	if !ok {
+		return "", false
+	}
+	args := make([]string, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, fmt.Sprintf("%s.%s", a, key))
+	}
+	return strings.Join(args, ", "), true
+}
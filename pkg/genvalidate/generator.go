@@ -3,101 +3,174 @@ package main
 import (
 	"fmt"
 	"go/ast"
-	"go/parser"
 	"go/token"
 	"os"
 	"os/exec"
-	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/henderiw/godantic/pkg/genvalidate/types"
 )
 
-const validationMarker = "// +generate:validate"
+const (
+	validationMarker     = "// +generate:validate"
+	validationImportPath = "github.com/henderiw/godantic/pkg/validation"
+)
+
+const (
+	statusSubresourceMarker = "// +kubebuilder:subresource:status"
+	deprecatedMarker        = "// +deprecated"
+)
 
 type StructInfo struct {
-	Name            string
-	Fields          []FieldInfo
-	HasNestedStruct bool
+	Name               string
+	Fields             []FieldInfo
+	HasNestedStruct    bool
 	HasValidationRules bool
+	HasImmutableFields bool
+	HasWarnings        bool
+	// StatusSubresource is true for resources whose status is updated
+	// through the status subresource.
+	StatusSubresource bool
+	// Recursive is true for types whose values can hold values of the same
+	// type.
+	Recursive bool
+	// Unions are the unions of optional fields of the struct.
+	Unions []Union
+	// Before and After are the hand-written methods called before and after
+	// the rules of the fields.
+	Before []string
+	After  []string
 }
 
+// EnumInfo is a named scalar type. Its values are validated against the
+// constants of the type, if any, and the rules documenting the type.
 type EnumInfo struct {
 	Name          string
 	Type          string
 	AllowedValues []string
+	Rules         []types.ValidationRule
+}
+
+// RuleInfo is a validation rule of a field.
+type RuleInfo struct {
+	Rule types.ValidationRule
+	// Groups are the validation groups the rule belongs to. Rules without
+	// groups always run.
+	Groups []string
+	// Warning is true for rules with the severity of a warning.
+	Warning bool
 }
 
+// Immutability defines if and when a field may change on update.
+type Immutability string
+
+const (
+	// Mutable fields may always change.
+	Mutable Immutability = ""
+	// Immutable fields may not change once the resource is created.
+	Immutable Immutability = "immutable"
+	// ImmutableOnceSet fields may be set on update if they were not set
+	// before, but may not change once set.
+	ImmutableOnceSet Immutability = "immutable_once_set"
+)
+
 type FieldInfo struct {
+	Field           *ast.Field
 	Name            string
+	JSONName        string
 	Type            ast.Expr
-	ValidationRules []types.ValidationRule
-	Node            *ast.File
-	NestedStruct    bool
+	ValidationRules []RuleInfo
+	// Presence are the rules requiring the field to be set, or not to be
+	// set, depending on the other fields.
+	Presence     []RuleInfo
+	Node         *ast.File
+	NestedStruct bool
+	Immutability Immutability
+	// Deprecated fields are reported as warning when they are set.
+	Deprecated bool
 }
 
 type FileInfo struct {
-	Path             string
-	Package          string
-	Structs          []StructInfo
-	Enums            []EnumInfo
-	HasNestedStructs bool
+	Path               string
+	Package            string
+	File               *File
+	Structs            []StructInfo
+	Enums              []EnumInfo
+	HasNestedStructs   bool
 	HasValidationRules bool
+	HasImmutableFields bool
+	HasWarnings        bool
 }
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run cmd/main.go <path>")
+		fmt.Println("Usage: go run ./pkg/genvalidate <path>")
 		os.Exit(1)
 	}
 	path := os.Args[1]
-	validategenerator := NewGenerator(path)
+	loader := NewLoader(path)
+	if err := loader.Load(); err != nil {
+		fmt.Println("Error loading", path, ":", err)
+		os.Exit(1)
+	}
+	validategenerator := NewGenerator(loader)
 	validategenerator.Generate()
 
+	deepcopygenerator := NewDeepCopyGenerator(loader)
+	deepcopygenerator.Generate()
+
+	equalgenerator := NewEqualGenerator(loader)
+	equalgenerator.Generate()
+
+	uniongenerator := NewUnionGenerator(loader)
+	if err := uniongenerator.Generate(); err != nil {
+		fmt.Println("Error generating unions:", err)
+		os.Exit(1)
+	}
+
+	normalizegenerator := NewNormalizeGenerator(loader)
+	if err := normalizegenerator.Generate(); err != nil {
+		fmt.Println("Error generating normalization:", err)
+		os.Exit(1)
+	}
 }
 
-func NewGenerator(path string) *Generator {
+func NewGenerator(loader *Loader) *Generator {
 	return &Generator{
-		path:     path,
+		loader:   loader,
 		registry: types.InitValidationRuleRegistry(),
 	}
 }
 
 type Generator struct {
-	path     string
+	loader   *Loader
 	registry map[string]types.ValidatorRuleParser
 }
 
 func (r *Generator) Generate() {
-	filepath.Walk(r.path, func(path string, info os.FileInfo, err error) error {
-		if err != nil || !strings.HasSuffix(path, ".go") {
-			return nil
-		}
-		fileInfo, err := r.processFile(path)
+	for _, file := range r.loader.Files() {
+		fileInfo, err := r.processFile(file)
 		if err != nil {
-			fmt.Println("Error processing", path, ":", err)
+			fmt.Println("Error processing", file.Path, ":", err)
+			continue
 		}
 		r.generateValidationCode(fileInfo)
-		return nil
-	})
-
+	}
 }
 
-func (r *Generator) processFile(path string) (*FileInfo, error) {
-	fs := token.NewFileSet()
-	node, err := parser.ParseFile(fs, path, nil, parser.AllErrors|parser.ParseComments)
-	if err != nil {
-		return nil, err
-	}
+func (r *Generator) processFile(file *File) (*FileInfo, error) {
+	node := file.Node
 	fileInfo := &FileInfo{
-		Path:    path,
+		Path:    file.Path,
 		Package: node.Name.Name, // Extract package name
+		File:    file,
 		Structs: []StructInfo{},
 		Enums:   []EnumInfo{},
 	}
 
-	fileHasNestedStructs := false
-	fileHasValidationRules := false
 	for _, decl := range node.Decls {
 		genDecl, ok := decl.(*ast.GenDecl)
 		if !ok || genDecl.Tok != token.TYPE {
@@ -129,103 +202,464 @@ func (r *Generator) processFile(path string) (*FileInfo, error) {
 			switch typeDecl := typeSpec.Type.(type) {
 			case *ast.StructType:
 				// Handle Structs
-				var hasNestedStruct bool
-				var hasValidationRules bool
-				var fields []FieldInfo
-				for _, field := range typeDecl.Fields.List {
-					if len(field.Names) == 0 {
-						continue
-					}
-
-					var validationRules []types.ValidationRule
-					var nestedStruct bool
-					skip := false
-					if field.Doc != nil {
-						for _, comment := range field.Doc.List {
-							if strings.HasPrefix(comment.Text, "// +validate(") {
-								funcName, attr, err := r.parseValidation(comment.Text)
-								if err != nil {
-									panic(err)
-								}
-								if funcName == "skip" {
-									skip = true
-									break
-								}
-								validationRule, err := r.parseValidationRule(funcName, attr)
-								if err != nil {
-									panic(err)
-								}
-								validationRules = append(validationRules, validationRule)
-								hasValidationRules = true
-								fileHasValidationRules = true
-							} else {
-								nestedStruct = isNestedStructOrEnum(field.Type, node)
-								if nestedStruct {
-									hasNestedStruct = true
-									fileHasNestedStructs = true
-								}
-							}
-						}
-					}
-
-					if !skip {
-						fields = append(fields, FieldInfo{
-							Name:            field.Names[0].Name,
-							Type:            field.Type,
-							ValidationRules: validationRules,
-							Node:            node,
-							NestedStruct:    nestedStruct,
-						})
-					}
+				structInfo, err := r.processStruct(file, typeSpec.Name.Name, typeDecl)
+				if err != nil {
+					return nil, err
 				}
-				fileInfo.Structs = append(fileInfo.Structs, StructInfo{
-					Name:            typeSpec.Name.Name,
-					Fields:          fields,
-					HasNestedStruct: hasNestedStruct,
-					HasValidationRules: hasValidationRules,
-				})
+				decl := r.loader.Lookup(file.ImportPath, typeSpec.Name.Name)
+				if err := r.structRules(decl, &structInfo); err != nil {
+					return nil, err
+				}
+				structInfo.StatusSubresource = decl.HasMarker(statusSubresourceMarker)
+				structInfo.Recursive = r.loader.isRecursive(decl)
+				structInfo.Unions, err = r.loader.unions(decl)
+				if err != nil {
+					return nil, err
+				}
+				structInfo.Before, structInfo.After, err = r.loader.hooks(decl)
+				if err != nil {
+					return nil, err
+				}
+				if len(structInfo.Unions) > 0 || len(structInfo.Before) > 0 || len(structInfo.After) > 0 {
+					structInfo.HasValidationRules = true
+				}
+				fileInfo.Structs = append(fileInfo.Structs, structInfo)
+				fileInfo.HasNestedStructs = fileInfo.HasNestedStructs || structInfo.HasNestedStruct
+				fileInfo.HasValidationRules = fileInfo.HasValidationRules || structInfo.HasValidationRules
+				fileInfo.HasImmutableFields = fileInfo.HasImmutableFields || structInfo.HasImmutableFields
+				fileInfo.HasWarnings = fileInfo.HasWarnings || structInfo.HasWarnings
 			case *ast.Ident:
 				// Handle Enum-like Types (Alias of string, int, etc.)
 				if baseType, isAlias := detectTypeAlias(node, typeSpec.Name.Name); isAlias {
 					allowedValues := extractEnumValues(node, typeSpec.Name.Name)
+					rules, err := r.typeRules(r.loader.Lookup(file.ImportPath, typeSpec.Name.Name))
+					if err != nil {
+						return nil, err
+					}
+					if len(rules) > 0 {
+						fileInfo.HasValidationRules = true
+					}
 					fileInfo.Enums = append(fileInfo.Enums, EnumInfo{
 						Name:          typeSpec.Name.Name,
 						Type:          baseType,
 						AllowedValues: allowedValues,
+						Rules:         rules,
 					})
 				}
 			}
 		}
 	}
-	fileInfo.HasNestedStructs = fileHasNestedStructs
-	fileInfo.HasValidationRules = fileHasValidationRules
 	return fileInfo, nil
 }
 
-func (r *Generator) parseValidation(comment string) (string, string, error) {
+// processStruct collects the validation of the fields of the struct type st
+// with the given name. Embedded fields are validated under the path of their
+// JSON name, or inlined in the parent.
+func (r *Generator) processStruct(file *File, name string, st *ast.StructType) (StructInfo, error) {
+	info := StructInfo{Name: name}
+	for _, field := range st.Fields.List {
+		goName := fieldNames(field)[0]
+		if goName == "" {
+			continue
+		}
+
+		var validationRules []RuleInfo
+		// nested values are validated unless the field is marked nodive
+		nestedStruct, err := r.needsValidation(file, field.Type)
+		if err != nil {
+			return info, fmt.Errorf("field %s: %w", goName, err)
+		}
+		dive := false
+		immutability := Mutable
+		skip := false
+		if field.Doc != nil {
+			for _, comment := range field.Doc.List {
+				if !strings.HasPrefix(comment.Text, "// +validate(") {
+					continue
+				}
+				marker, err := r.parseValidation(comment.Text)
+				if err != nil {
+					panic(err)
+				}
+				if marker.Name == "skip" {
+					skip = true
+					break
+				}
+				if marker.Name == "dive" || marker.Name == "nodive" {
+					if dive {
+						return info, fmt.Errorf("conflicting dive markers of field %s", goName)
+					}
+					dive = true
+					if marker.Name == "dive" && !nestedStruct {
+						return info, fmt.Errorf("invalid dive marker of field %s: its values have no validator", goName)
+					}
+					nestedStruct = marker.Name == "dive"
+					continue
+				}
+				if marker.Name == string(Immutable) || marker.Name == string(ImmutableOnceSet) {
+					immutability = Immutability(marker.Name)
+					info.HasImmutableFields = true
+					continue
+				}
+				validationRule, err := r.parseValidationRule(marker.Name, marker.Attrs)
+				if err != nil {
+					panic(err)
+				}
+				warning, err := marker.warning()
+				if err != nil {
+					panic(err)
+				}
+				validationRules = append(validationRules, RuleInfo{
+					Rule:    validationRule,
+					Groups:  marker.groups(),
+					Warning: warning,
+				})
+				if warning {
+					info.HasWarnings = true
+				}
+				info.HasValidationRules = true
+			}
+		}
+		if skip {
+			continue
+		}
+		validationRules, err = r.uniqueRules(file, field, validationRules)
+		if err != nil {
+			return info, fmt.Errorf("field %s: %w", goName, err)
+		}
+		validationRules, presence, err := presenceRules(st, validationRules)
+		if err != nil {
+			return info, fmt.Errorf("field %s: %w", goName, err)
+		}
+		if len(validationRules) > 0 {
+			info.HasValidationRules = true
+		}
+		if nestedStruct {
+			info.HasNestedStruct = true
+		}
+
+		deprecated := hasFieldMarker(field, deprecatedMarker)
+		if deprecated {
+			info.HasWarnings = true
+		}
+		jsonName, inline := jsonName(field)
+		if inline {
+			// the fields of an inlined embedded struct are reported at the
+			// path of the parent
+			jsonName = ""
+		}
+		info.Fields = append(info.Fields, FieldInfo{
+			Field:           field,
+			Name:            goName,
+			JSONName:        jsonName,
+			Type:            field.Type,
+			ValidationRules: validationRules,
+			Presence:        presence,
+			Node:            file.Node,
+			NestedStruct:    nestedStruct,
+			Immutability:    immutability,
+			Deprecated:      deprecated,
+		})
+	}
+	return info, nil
+}
+
+// presenceRules separates the presence rules of a field of the struct st
+// from its other rules, and resolves the fields their conditions refer to.
+// The presence of a field is checked before its value is dereferenced.
+func presenceRules(st *ast.StructType, rules []RuleInfo) (values, presence []RuleInfo, err error) {
+	resolve := func(name string) (string, string, bool) {
+		_, goName, jsonName, ok := structField(st, name)
+		return goName, jsonName, ok
+	}
+	for _, rule := range rules {
+		p, ok := rule.Rule.(*types.Presence)
+		if !ok {
+			values = append(values, rule)
+			continue
+		}
+		if err := p.Resolve(resolve); err != nil {
+			return nil, nil, err
+		}
+		presence = append(presence, rule)
+	}
+	return values, presence, nil
+}
+
+// structRules adds the presence rules documenting the struct type decl to
+// the fields named by their target option, e.g.
+// `// +validate(required_with(fields=[password]), target=authentication)`,
+// which keeps the conditions across fields together with the type.
+func (r *Generator) structRules(decl *TypeDecl, info *StructInfo) error {
+	doc := decl.Doc()
+	if doc == nil {
+		return nil
+	}
+	st := decl.Spec.Type.(*ast.StructType)
+	for _, comment := range doc.List {
+		if !strings.HasPrefix(comment.Text, "// +validate(") {
+			continue
+		}
+		if _, _, ok := parseHook(comment.Text); ok {
+			continue
+		}
+		marker, err := r.parseValidation(comment.Text)
+		if err != nil {
+			return err
+		}
+		rule, err := r.parseValidationRule(marker.Name, marker.Attrs)
+		if err != nil {
+			return fmt.Errorf("invalid rule of type %s: %w", decl.Name, err)
+		}
+		if _, ok := rule.(*types.Presence); !ok {
+			return fmt.Errorf("invalid rule of type %s: only presence rules apply to struct types, got: %s", decl.Name, comment.Text)
+		}
+		target, ok := marker.Options["target"]
+		if !ok {
+			return fmt.Errorf("invalid rule of type %s: the rule requires a target field, got: %s", decl.Name, comment.Text)
+		}
+		_, goName, _, ok := structField(st, target)
+		index := slices.IndexFunc(info.Fields, func(field FieldInfo) bool { return field.Name == goName })
+		if !ok || index < 0 {
+			return fmt.Errorf("invalid rule of type %s: unknown or skipped target field %s", decl.Name, target)
+		}
+		warning, err := marker.warning()
+		if err != nil {
+			return err
+		}
+		_, presence, err := presenceRules(st, []RuleInfo{{Rule: rule, Groups: marker.groups(), Warning: warning}})
+		if err != nil {
+			return fmt.Errorf("invalid rule of type %s: %w", decl.Name, err)
+		}
+		info.Fields[index].Presence = append(info.Fields[index].Presence, presence...)
+		info.HasValidationRules = true
+		info.HasWarnings = info.HasWarnings || warning
+	}
+	return nil
+}
+
+// uniqueRules completes the unique rules of the list field, whose keys may
+// be given by JSON or Go name, and adds the unique rule implied by the list
+// type: the keys of a list of type map and the elements of a list of type
+// set are unique.
+func (r *Generator) uniqueRules(file *File, field *ast.Field, rules []RuleInfo) ([]RuleInfo, error) {
+	elt := elemType(derefType(field.Type))
+	if elt == nil {
+		return rules, nil
+	}
+	decl := r.loader.Resolve(file, derefType(elt))
+	explicit := false
+	for _, rule := range rules {
+		unique, ok := rule.Rule.(*types.Unique)
+		if !ok {
+			continue
+		}
+		explicit = true
+		if unique.Keys == nil {
+			if !r.loader.isComparable(file, derefType(elt)) {
+				return nil, fmt.Errorf("unique requires keys for elements that are not comparable")
+			}
+			continue
+		}
+		if err := r.resolveUniqueKeys(decl, unique); err != nil {
+			return nil, err
+		}
+	}
+	if explicit {
+		return rules, nil
+	}
+	if keys, ok := r.loader.listMapKeyFields(file, field, derefType(elt)); ok {
+		unique := &types.Unique{Keys: &keys}
+		if err := r.resolveUniqueKeys(decl, unique); err != nil {
+			return nil, err
+		}
+		return append(rules, RuleInfo{Rule: unique}), nil
+	}
+	if listType := fieldMarkers(field, "listType"); len(listType) > 0 && listType[len(listType)-1] == "set" && r.loader.isScalar(file, derefType(elt)) {
+		return append(rules, RuleInfo{Rule: &types.Unique{}}), nil
+	}
+	return rules, nil
+}
+
+// resolveUniqueKeys replaces the keys of the unique rule, given by JSON or Go
+// name, with the Go names of the fields of the struct type decl of the
+// elements. Key fields whose values cannot be compared as map keys, e.g.
+// slices, are rejected.
+func (r *Generator) resolveUniqueKeys(decl *TypeDecl, unique *types.Unique) error {
+	if decl == nil {
+		return fmt.Errorf("unique keys require elements of a struct type of the tree")
+	}
+	return unique.Resolve(func(key string) (string, bool, error) {
+		if goName, ok := r.loader.structFieldByJSONName(decl, key); ok {
+			key = goName
+		}
+		file, typ, ok := r.loader.structFieldType(decl, key)
+		if !ok {
+			return "", false, fmt.Errorf("unique refers to unknown field %s", key)
+		}
+		if !r.loader.isComparable(file, derefType(typ)) {
+			return "", false, fmt.Errorf("unique key %s is not comparable", key)
+		}
+		_, pointer := typ.(*ast.StarExpr)
+		return key, pointer, nil
+	})
+}
+
+// needsValidation returns true if the values of type expr are validated,
+// including the values of anonymous struct types with validated fields.
+func (r *Generator) needsValidation(file *File, expr ast.Expr) (bool, error) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return r.needsValidation(file, t.X)
+	case *ast.ArrayType:
+		return r.needsValidation(file, t.Elt)
+	case *ast.MapType:
+		return r.needsValidation(file, t.Value)
+	case *ast.StructType:
+		info, err := r.processStruct(file, "", t)
+		if err != nil {
+			return false, err
+		}
+		return info.validates(validateRatcheting), nil
+	}
+	return r.loader.needsValidation(file, expr), nil
+}
+
+// validates returns true if the generated validation of the struct in the
+// given mode has any check.
+func (r StructInfo) validates(mode validationMode) bool {
+	return r.HasNestedStruct || r.HasValidationRules || r.HasWarnings || (mode != validateCreate && r.HasImmutableFields)
+}
+
+// typeRules returns the rules documenting a named scalar type, which apply
+// to every value of the type.
+func (r *Generator) typeRules(decl *TypeDecl) ([]types.ValidationRule, error) {
+	doc := decl.Doc()
+	if doc == nil {
+		return nil, nil
+	}
+	var rules []types.ValidationRule
+	for _, comment := range doc.List {
+		if !strings.HasPrefix(comment.Text, "// +validate(") {
+			continue
+		}
+		marker, err := r.parseValidation(comment.Text)
+		if err != nil {
+			return nil, err
+		}
+		if len(marker.Options) > 0 {
+			return nil, fmt.Errorf("invalid rule of type %s: options are not supported on types, got: %s", decl.Name, comment.Text)
+		}
+		rule, err := r.parseValidationRule(marker.Name, marker.Attrs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule of type %s: %w", decl.Name, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// validateMarker is a parsed `// +validate(...)` marker, e.g.
+// `// +validate(length(min = 1), groups=[create])`.
+type validateMarker struct {
+	// Name is the name of the validator, e.g. length.
+	Name string
+	// Attrs are the attributes of the validator, e.g. `min = 1`.
+	Attrs string
+	// Options are the options following the validator, e.g. groups.
+	Options map[string]string
+}
+
+// warning returns true if the marker sets the severity of a warning.
+func (m *validateMarker) warning() (bool, error) {
+	switch severity := m.Options["severity"]; severity {
+	case "", "error":
+		return false, nil
+	case "warning":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported severity: %s", severity)
+	}
+}
+
+// groups returns the validation groups of the marker.
+func (m *validateMarker) groups() []string {
+	value, ok := m.Options["groups"]
+	if !ok {
+		return nil
+	}
+	var groups []string
+	for _, group := range strings.Split(strings.Trim(value, "[]"), ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+func (r *Generator) parseValidation(comment string) (*validateMarker, error) {
 	comment = strings.TrimSpace(comment)
 	if !strings.HasPrefix(comment, "// +validate(") {
-		return "", "", fmt.Errorf("unexpected comment, expected `// +validate` prefix got: %s", comment)
+		return nil, fmt.Errorf("unexpected comment, expected `// +validate` prefix got: %s", comment)
 	}
 
 	// Extract content inside `()`
 	start := strings.Index(comment, "(")
 	end := strings.LastIndex(comment, ")")
 	if start == -1 || end == -1 || start > end {
-		fmt.Println("Invalid validate syntax")
-		return "", "", fmt.Errorf("invalid validate syntax got: %s", comment)
+		return nil, fmt.Errorf("invalid validate syntax got: %s", comment)
 	}
 
-	content := comment[start+1 : end] // Get `length(min = 4, max = 5, equal = 5)`
+	content := comment[start+1 : end] // Get `length(min = 4, max = 5, equal = 5), groups=[create]`
+	args := splitTopLevel(content)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("invalid validate syntax got: %s", comment)
+	}
 
 	// Split at the first `(` to get `length`
-	parts := strings.SplitN(content, "(", 2)
-	funcName := strings.TrimSpace(parts[0])
-	if len(parts) == 1 {
-		return funcName, "", nil
+	marker := &validateMarker{Options: map[string]string{}}
+	parts := strings.SplitN(args[0], "(", 2)
+	marker.Name = strings.TrimSpace(parts[0])
+	if len(parts) == 2 {
+		marker.Attrs = strings.TrimSuffix(strings.TrimSpace(parts[1]), ")")
 	}
-	attrs := strings.TrimSuffix(parts[1], ")")
-	return funcName, attrs, nil
+	for _, option := range args[1:] {
+		key, value, ok := strings.Cut(option, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid validate option %q got: %s", option, comment)
+		}
+		marker.Options[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return marker, nil
+}
+
+// splitTopLevel splits s at the commas that are not nested in brackets or
+// quotes.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote rune
+	for i, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '`':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if rest := strings.TrimSpace(s[start:]); rest != "" {
+		parts = append(parts, rest)
+	}
+	return parts
 }
 
 func (r *Generator) parseValidationRule(funcName, attrs string) (types.ValidationRule, error) {
@@ -239,62 +673,97 @@ func (r *Generator) parseValidationRule(funcName, attrs string) (types.Validatio
 
 func (r *Generator) generateValidationCode(fileInfo *FileInfo) {
 	outputFile := strings.TrimSuffix(fileInfo.Path, ".go") + "_validate.go"
-	var sb strings.Builder
-	sb.WriteString("// GENERATED CODE - DO NOT EDIT\n")
-	sb.WriteString(fmt.Sprintf("package %s\n\n", fileInfo.Package)) // Use actual package name
-	sb.WriteString("import (\n")
-	if len(fileInfo.Enums) > 0 || fileInfo.HasValidationRules {
-		sb.WriteString("\t\"fmt\"\n")
+	imports := map[string]string{}
+
+	if fileInfo.HasNestedStructs || fileInfo.HasValidationRules || fileInfo.HasImmutableFields || fileInfo.HasWarnings {
+		imports["errors"] = ""
+	}
+	if len(fileInfo.Enums) > 0 || len(fileInfo.Structs) > 0 {
+		imports[validationImportPath] = ""
 	}
-	if fileInfo.HasNestedStructs {
-		fmt.Println(fileInfo.Path, len(fileInfo.Structs), fileInfo.HasNestedStructs)
-		sb.WriteString("\t\"errors\"\n")
+	if len(fileInfo.Structs) > 0 {
+		imports["context"] = ""
 	}
-	sb.WriteString("\t)\n")
 
+	var sb strings.Builder
 	for _, enumInfo := range fileInfo.Enums {
 		sb.WriteString(fmt.Sprintf("func (r %s) Validate() error {\n", enumInfo.Name))
-		sb.WriteString(generateEnumValidation(enumInfo.Name, enumInfo.Type, enumInfo.AllowedValues))
+		sb.WriteString(generateEnumValidation(enumInfo))
 		sb.WriteString("}\n")
 	}
 	for _, schemaInfo := range fileInfo.Structs {
 		sb.WriteString(fmt.Sprintf("func (r *%s) Validate() error {\n", schemaInfo.Name))
-		if schemaInfo.Name == "ConditionedStatus" {
-			fmt.Println("ConditionedStatus", schemaInfo)
-		}
-		if schemaInfo.HasNestedStruct {
-			sb.WriteString("\tvar errs error\n")
-		}
+		sb.WriteString("return r.ValidateContext(context.Background(), validation.Options{Groups: []string{validation.GroupCreate}})\n")
+		sb.WriteString("}\n\n")
 
-		for _, fieldInfo := range schemaInfo.Fields {
-			for _, rule := range fieldInfo.ValidationRules {
-				fieldName := fieldInfo.Name
-				fieldNameCode := fmt.Sprintf("r.%s", fieldName)
-				if isPointerType(fieldInfo.Type) {
-					sb.WriteString(fmt.Sprintf("if r.%s != nil {\n", fieldName))
-					fieldNameCode = fmt.Sprintf("*r.%s", fieldName)
-					fieldName = "*" + fieldName // Dereference pointer for validation
-				}
-				
-				sb.WriteString(rule.ExpandCode(fieldName, fieldNameCode)) // Expand the code based on the rule
-				if isPointerType(fieldInfo.Type) {
-					sb.WriteString("}\n") // Close the pointer check block
-				}
-			}
-			// nested code generation is implicitly enabled
-			// when a struct exists we generate the nested validation rules
-			if fieldInfo.NestedStruct {
-				sb.WriteString(generateNestedStructs(fieldInfo, fieldInfo.Type, fmt.Sprintf("r.%s", fieldInfo.Name)))
-			}
+		sb.WriteString("// ValidateWith validates the receiver with the rules of the requested groups\n")
+		sb.WriteString("// and the rules without groups.\n")
+		sb.WriteString(fmt.Sprintf("func (r *%s) ValidateWith(opts validation.Options) error {\n", schemaInfo.Name))
+		sb.WriteString("return r.ValidateContext(context.Background(), opts)\n")
+		sb.WriteString("}\n\n")
+
+		sb.WriteString("// ValidateContext validates the receiver like ValidateWith. It stops when ctx\n")
+		sb.WriteString("// is done or when the errors reach the limit of the options.\n")
+		sb.WriteString(fmt.Sprintf("func (r *%s) ValidateContext(ctx context.Context, opts validation.Options) error {\n", schemaInfo.Name))
+		sb.WriteString(enterRecursive(schemaInfo))
+		if status := statusField(schemaInfo); status != nil && r.loader.isValidatedStruct(fileInfo.File, status.Type) {
+			sb.WriteString("if opts.Has(validation.GroupStatus) {\n")
+			sb.WriteString("// the status subresource only validates the status\n")
+			sb.WriteString(fmt.Sprintf("return validation.Prefix(%q, r.%s.ValidateContext(ctx, opts))\n", status.JSONName, status.Name))
+			sb.WriteString("}\n")
 		}
-		if schemaInfo.HasNestedStruct {
-			sb.WriteString("\tif errs != nil{ return errs }\n")
+		sb.WriteString(r.generateStructValidation(fileInfo, schemaInfo, validateCreate, imports))
+		sb.WriteString("}\n\n")
+
+		sb.WriteString("// ValidateUpdate validates the receiver as an update of old. In addition to\n")
+		sb.WriteString("// the rules of Validate it reports changes of immutable fields.\n")
+		sb.WriteString(fmt.Sprintf("func (r *%s) ValidateUpdate(old *%s) error {\n", schemaInfo.Name, schemaInfo.Name))
+		sb.WriteString("return r.ValidateUpdateContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})\n")
+		sb.WriteString("}\n\n")
+
+		sb.WriteString("// ValidateUpdateContext validates the receiver as an update of old like\n")
+		sb.WriteString("// ValidateUpdate with the rules of the requested groups and the rules without\n")
+		sb.WriteString("// groups. It stops like ValidateContext.\n")
+		sb.WriteString(fmt.Sprintf("func (r *%s) ValidateUpdateContext(ctx context.Context, old *%s, opts validation.Options) error {\n", schemaInfo.Name, schemaInfo.Name))
+		sb.WriteString("if old == nil {\nreturn r.ValidateContext(ctx, opts)\n}\n")
+		sb.WriteString(enterRecursive(schemaInfo))
+		sb.WriteString(r.generateStructValidation(fileInfo, schemaInfo, validateUpdate, imports))
+		sb.WriteString("}\n\n")
+
+		sb.WriteString("// ValidateRatcheting validates the receiver as an update of old like\n")
+		sb.WriteString("// ValidateUpdate, but ignores the failures of values unchanged from old, so\n")
+		sb.WriteString("// objects stored before a rule was tightened can still be updated.\n")
+		sb.WriteString(fmt.Sprintf("func (r *%s) ValidateRatcheting(old *%s) error {\n", schemaInfo.Name, schemaInfo.Name))
+		sb.WriteString("return r.ValidateRatchetingContext(context.Background(), old, validation.Options{Groups: []string{validation.GroupUpdate}})\n")
+		sb.WriteString("}\n\n")
+
+		sb.WriteString("// ValidateRatchetingContext validates the receiver like ValidateRatcheting with\n")
+		sb.WriteString("// the rules of the requested groups and the rules without groups. It stops\n")
+		sb.WriteString("// like ValidateContext.\n")
+		sb.WriteString(fmt.Sprintf("func (r *%s) ValidateRatchetingContext(ctx context.Context, old *%s, opts validation.Options) error {\n", schemaInfo.Name, schemaInfo.Name))
+		sb.WriteString("if old == nil {\nreturn r.ValidateContext(ctx, opts)\n}\n")
+		sb.WriteString(enterRecursive(schemaInfo))
+		sb.WriteString(r.generateStructValidation(fileInfo, schemaInfo, validateRatcheting, imports))
+		sb.WriteString("}\n\n")
+
+		if status := statusField(schemaInfo); status != nil && r.loader.isValidatedStruct(fileInfo.File, status.Type) {
+			sb.WriteString("// ValidateStatusUpdate validates the receiver as an update of old through the\n")
+			sb.WriteString("// status subresource, which only updates the status.\n")
+			sb.WriteString(fmt.Sprintf("func (r *%s) ValidateStatusUpdate(old *%s) error {\n", schemaInfo.Name, schemaInfo.Name))
+			sb.WriteString("opts := validation.Options{Groups: []string{validation.GroupUpdate, validation.GroupStatus}}\n")
+			sb.WriteString("if old == nil {\nreturn r.ValidateWith(opts)\n}\n")
+			sb.WriteString(fmt.Sprintf("return validation.Prefix(%q, r.%s.ValidateUpdateContext(context.Background(), &old.%s, opts))\n", status.JSONName, status.Name, status.Name))
+			sb.WriteString("}\n\n")
 		}
-		sb.WriteString("\treturn nil\n")
-		sb.WriteString("}\n")
 	}
 	if len(fileInfo.Enums) > 0 || len(fileInfo.Structs) > 0 {
-		err := os.WriteFile(outputFile, []byte(sb.String()), 0644)
+		var out strings.Builder
+		out.WriteString(generatedHeader + "\n")
+		out.WriteString(fmt.Sprintf("package %s\n\n", fileInfo.Package)) // Use actual package name
+		writeImports(&out, imports)
+		out.WriteString(sb.String())
+
+		err := os.WriteFile(outputFile, []byte(out.String()), 0644)
 		if err != nil {
 			fmt.Println("Error writing validation file:", err)
 			return
@@ -308,79 +777,580 @@ func (r *Generator) generateValidationCode(fileInfo *FileInfo) {
 
 }
 
-func isNestedStructOrEnum(expr ast.Expr, node *ast.File) bool {
-	switch t := expr.(type) {
-	case *ast.StarExpr: // Pointer to a struct (e.g., *MyStruct)
-		return isNestedStructOrEnum(t.X, node)
-	case *ast.StructType: // Direct struct type
-		return true
-	case *ast.SelectorExpr: // External package struct (e.g., mypkg.MyStruct)
-		return true
-	case *ast.Ident:
-		return isDeclaredAsStructOrEnum(t.Name, node)
-	case *ast.ArrayType: // Array (e.g., []MyStruct)
-		fmt.Println("array type")
-		return isNestedStructOrEnum(t.Elt, node) // Recursively check element type
-	case *ast.MapType: // Map (e.g., map[string]MyStruct)
-		return isNestedStructOrEnum(t.Value, node) // Recursively check map value type
-	default:
-		return false
+// containers returns the lists and maps the wrapper rules of a field of
+// type expr range over, from the outermost to the innermost.
+func (r *Generator) containers(file *File, expr ast.Expr) []types.Container {
+	var containers []types.Container
+	for {
+		switch t := derefType(expr).(type) {
+		case *ast.ArrayType:
+			_, pointer := t.Elt.(*ast.StarExpr)
+			containers = append(containers, types.Container{Pointer: pointer})
+			expr = derefType(t.Elt)
+			continue
+		case *ast.MapType:
+			_, pointer := t.Value.(*ast.StarExpr)
+			containers = append(containers, types.Container{Pointer: pointer, Ordered: r.loader.isOrdered(file, t.Key)})
+			expr = derefType(t.Value)
+			continue
+		}
+		return containers
+	}
+}
+
+// enterRecursive returns the statements guarding the validation of a value
+// of a recursive type against cycles and unbounded nesting.
+func enterRecursive(schemaInfo StructInfo) string {
+	if !schemaInfo.Recursive {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("// the type is recursive\n")
+	sb.WriteString("opts, err := opts.Enter(r)\n")
+	sb.WriteString("if err != nil {\nreturn err\n}\n")
+	return sb.String()
+}
+
+// validationMode defines how a generated method validates a struct.
+type validationMode int
+
+const (
+	// validateCreate validates the receiver on its own.
+	validateCreate validationMode = iota
+	// validateUpdate validates the receiver as an update of an old object.
+	validateUpdate
+	// validateRatcheting validates the receiver as an update of an old object
+	// and ignores the failures of unchanged values.
+	validateRatcheting
+)
+
+// method returns the name of the generated method validating a struct in
+// the mode.
+func (m validationMode) method() string {
+	switch m {
+	case validateUpdate:
+		return "ValidateUpdateContext"
+	case validateRatcheting:
+		return "ValidateRatchetingContext"
 	}
+	return "ValidateContext"
 }
 
-func generateNestedStructs(fieldInfo FieldInfo, expr ast.Expr, fieldName string) string {
+// generateStructValidation generates the body of the validation of a struct
+// in the given mode.
+func (r *Generator) generateStructValidation(fileInfo *FileInfo, schemaInfo StructInfo, mode validationMode, imports map[string]string) string {
+	var sb strings.Builder
+	hasErrs := schemaInfo.validates(mode)
+	if hasErrs {
+		sb.WriteString("\tvar errs error\n")
+	}
+	sb.WriteString(generateHooks(schemaInfo.Before))
+
+	for _, fieldInfo := range schemaInfo.Fields {
+		if schemaInfo.StatusSubresource && fieldInfo.JSONName == "status" {
+			if fieldInfo.NestedStruct {
+				sb.WriteString("// the status is only updated through the status subresource\n")
+			}
+			continue
+		}
+		fieldStart := sb.Len()
+		ratchet := mode == validateRatcheting && (len(fieldInfo.ValidationRules) > 0 || fieldInfo.NestedStruct)
+		if ratchet {
+			// unchanged values are not validated again
+			sb.WriteString(fmt.Sprintf("if %s {\n", r.changed(fileInfo.File, fieldInfo.Type, "r."+fieldInfo.Name, "old."+fieldInfo.Name, imports)))
+		}
+		for _, rule := range fieldInfo.ValidationRules {
+			fieldName := fieldInfo.JSONName
+			fieldNameCode := fmt.Sprintf("r.%s", fieldInfo.Name)
+			var code strings.Builder
+			if isPointerType(fieldInfo.Type) {
+				code.WriteString(fmt.Sprintf("if r.%s != nil {\n", fieldInfo.Name))
+				fieldNameCode = fmt.Sprintf("*r.%s", fieldInfo.Name) // Dereference pointer for validation
+			}
+
+			// Expand the code based on the rule; wrappers range over the containers of the field
+			code.WriteString(types.ExpandNested(rule.Rule, strconv.Quote(fieldName), fieldNameCode, r.containers(fileInfo.File, derefType(fieldInfo.Type))))
+			if isPointerType(fieldInfo.Type) {
+				code.WriteString("}\n") // Close the pointer check block
+			}
+			sb.WriteString(wrapRule(rule, code.String()))
+		}
+		// nested code generation is implicitly enabled
+		// when a struct exists we generate the nested validation rules
+		if fieldInfo.NestedStruct {
+			oldName := ""
+			if mode != validateCreate {
+				oldName = fmt.Sprintf("&old.%s", fieldInfo.Name)
+				if isPointerType(fieldInfo.Type) {
+					oldName = fmt.Sprintf("old.%s", fieldInfo.Name)
+				}
+			}
+			if ratchet && !isContainer(fieldInfo.Type) && !isAnonymousStruct(fieldInfo.Type) && !r.loader.isValidatedStruct(fileInfo.File, derefType(fieldInfo.Type)) {
+				// the field is only validated when it changed
+				oldName = ""
+			}
+			nested := nestedValidation{
+				file:    fileInfo.File,
+				mode:    mode,
+				imports: imports,
+			}
+			if elt := elemType(fieldInfo.Type); elt != nil {
+				nested.listKeys, _ = r.loader.listMapKeyFields(fileInfo.File, fieldInfo.Field, elt)
+			}
+			sb.WriteString(r.generateNestedStructs(nested, fieldInfo.Type, fmt.Sprintf("r.%s", fieldInfo.Name), oldName, fmt.Sprintf("%q", fieldInfo.JSONName)))
+		}
+		if ratchet {
+			sb.WriteString("}\n")
+		}
+		for _, rule := range fieldInfo.Presence {
+			code := rule.Rule.ExpandCode(strconv.Quote(fieldInfo.JSONName), "r."+fieldInfo.Name)
+			if mode == validateRatcheting {
+				// the presence is checked again when the field or the fields
+				// of the condition changed
+				code = fmt.Sprintf("if %s {\n%s}\n", r.presenceChanged(fileInfo.File, schemaInfo, fieldInfo, rule, imports), code)
+			}
+			sb.WriteString(wrapRule(rule, code))
+		}
+		if mode != validateCreate && fieldInfo.Immutability != Mutable {
+			sb.WriteString(r.generateImmutable(fileInfo.File, fieldInfo, imports))
+		}
+		if fieldInfo.Deprecated {
+			sb.WriteString(fmt.Sprintf("if opts.Warnings && !validation.IsZero(r.%s) {\n", fieldInfo.Name))
+			sb.WriteString(fmt.Sprintf("\terrs = errors.Join(errs, validation.Deprecated(%q))\n", fieldInfo.JSONName))
+			sb.WriteString("}\n")
+		}
+		if sb.Len() > fieldStart {
+			sb.WriteString(exceededCheck)
+		}
+	}
+	for _, union := range schemaInfo.Unions {
+		code := generateUnion(union)
+		if mode == validateRatcheting {
+			// the union is checked again when any of its fields changed
+			code = fmt.Sprintf("if %s {\n%s}\n", r.fieldsChanged(fileInfo.File, schemaInfo, unionFields(union), imports), code)
+		}
+		sb.WriteString(code)
+		sb.WriteString(exceededCheck)
+	}
+	sb.WriteString(generateHooks(schemaInfo.After))
+	if hasErrs {
+		sb.WriteString("\tif errs != nil{ return errs }\n")
+	}
+	sb.WriteString("\treturn nil\n")
+	return sb.String()
+}
+
+// wrapRule returns the code of the rule restricted to its validation groups,
+// and reporting its failures as warnings if the rule has the severity of a
+// warning.
+func wrapRule(rule RuleInfo, code string) string {
+	var sb strings.Builder
+	var conditions []string
+	if len(rule.Groups) > 0 {
+		conditions = append(conditions, fmt.Sprintf("opts.Has(%s)", quoteAll(rule.Groups)))
+	}
+	if rule.Warning {
+		conditions = append(conditions, "opts.Warnings")
+	}
+	if len(conditions) > 0 {
+		sb.WriteString(fmt.Sprintf("if %s {\n", strings.Join(conditions, " && ")))
+	}
+	if rule.Warning {
+		// the failures of the rule are collected and reported as warnings
+		sb.WriteString("var warnings error\n{\nvar errs error\n")
+	}
+	sb.WriteString(code)
+	if rule.Warning {
+		sb.WriteString("warnings = errs\n}\n")
+		sb.WriteString("errs = errors.Join(errs, validation.Warn(warnings))\n")
+	}
+	if len(conditions) > 0 {
+		sb.WriteString("}\n") // Close the group and severity check block
+	}
+	return sb.String()
+}
+
+// presenceChanged returns the Go expression that is true if the field with
+// the presence rule, or any field of its condition, changed.
+func (r *Generator) presenceChanged(file *File, schemaInfo StructInfo, fieldInfo FieldInfo, rule RuleInfo, imports map[string]string) string {
+	names := append([]string{fieldInfo.Name}, rule.Rule.(*types.Presence).Refs()...)
+	return r.fieldsChanged(file, schemaInfo, names, imports)
+}
+
+// fieldsChanged returns the Go expression that is true if any of the fields
+// of the struct with the given Go names changed.
+func (r *Generator) fieldsChanged(file *File, schemaInfo StructInfo, names []string, imports map[string]string) string {
+	var changed []string
+	for _, name := range names {
+		value, oldValue := "r."+name, "old."+name
+		check := fmt.Sprintf("!validation.DeepEqual(%s, %s)", value, oldValue)
+		for _, f := range schemaInfo.Fields {
+			if f.Name == name {
+				check = r.changed(file, f.Type, value, oldValue, imports)
+			}
+		}
+		changed = append(changed, check)
+	}
+	return strings.Join(changed, " || ")
+}
+
+// generateImmutable generates the check reporting a change of an immutable
+// field on update.
+func (r *Generator) generateImmutable(file *File, fieldInfo FieldInfo, imports map[string]string) string {
+	value, oldValue := "r."+fieldInfo.Name, "old."+fieldInfo.Name
+	changed := r.changed(file, fieldInfo.Type, value, oldValue, imports)
+	if fieldInfo.Immutability == ImmutableOnceSet {
+		// the field may be set when it was not set before
+		changed = fmt.Sprintf("!validation.IsZero(%s) && %s", oldValue, changed)
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("if %s {\n", changed))
+	sb.WriteString(fmt.Sprintf("\terrs = errors.Join(errs, validation.Immutable(%q))\n", fieldInfo.JSONName))
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// changed returns the Go expression that is true if the values a and b of
+// type expr differ, and records the packages it refers to.
+func (r *Generator) changed(file *File, expr ast.Expr, a, b string, imports map[string]string) string {
+	changed := r.loader.differs(file, expr, a, b)
+	if strings.HasPrefix(changed, "!diff.") {
+		imports[diffImportPath] = ""
+	}
+	return changed
+}
+
+// quoteAll returns the quoted strings separated by commas.
+func quoteAll(s []string) string {
+	quoted := make([]string, 0, len(s))
+	for _, v := range s {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// statusField returns the status field of a resource with a status
+// subresource, or nil.
+func statusField(schemaInfo StructInfo) *FieldInfo {
+	if !schemaInfo.StatusSubresource {
+		return nil
+	}
+	for i := range schemaInfo.Fields {
+		if schemaInfo.Fields[i].JSONName == "status" {
+			return &schemaInfo.Fields[i]
+		}
+	}
+	return nil
+}
+
+const (
+	// contextCheck stops the validation of the elements of a list or map when
+	// the context is done.
+	contextCheck = "if err := ctx.Err(); err != nil {\nreturn errors.Join(errs, err)\n}\n"
+	// exceededCheck stops the validation when the errors reach the limit of
+	// the options.
+	exceededCheck = "if opts.Exceeded(errs) {\nreturn errs\n}\n"
+)
+
+// nestedValidation holds the state of the generation of the validation of a
+// nested value.
+type nestedValidation struct {
+	file *File
+	mode validationMode
+	// listKeys are the Go names of the key fields of the elements of a list
+	// of type map, which correlate the elements with the old list.
+	listKeys []string
+	imports  map[string]string
+	// depth is the number of enclosing loops over lists and maps.
+	depth int
+	// pointer is true if the nested value is a pointer.
+	pointer bool
+}
+
+// loopVar returns the name of a loop variable, unique within nested loops.
+func (r nestedValidation) loopVar(name string) string {
+	if r.depth == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s%d", name, r.depth)
+}
+
+// generateNestedStructs generates the validation of the nested value
+// fieldName of type expr, reporting its errors under the path the Go
+// expression path evaluates to. When oldName is set the value is validated as
+// an update of the old value oldName points to, which may be nil.
+func (r *Generator) generateNestedStructs(nested nestedValidation, expr ast.Expr, fieldName, oldName, path string) string {
 	var sb strings.Builder
 
 	switch t := expr.(type) {
 	case *ast.StarExpr:
 		// If it's a pointer, wrap validation inside `if != nil`
 		sb.WriteString(fmt.Sprintf("if %s != nil {\n", fieldName))
-		sb.WriteString(generateNestedStructs(fieldInfo, t.X, fieldName))
+		value := fieldName
+		if isContainer(t.X) {
+			// lists and maps are ranged over by value
+			value = fmt.Sprintf("(*%s)", fieldName)
+		} else {
+			nested.pointer = true
+		}
+		sb.WriteString(r.generateNestedStructs(nested, t.X, value, oldName, path))
 		sb.WriteString("}\n")
 
-	// the ast.ident we blindly use since we have done the validation before (ast.Ident is s struct in the same file)
-	case *ast.StructType, *ast.SelectorExpr, *ast.Ident:
-		// If it's a struct, call Validate()
-		sb.WriteString(fmt.Sprintf("if err := %s.Validate(); err != nil {\n", fieldName))
-		sb.WriteString("\terrs = errors.Join(errs, err)\n")
+	case *ast.StructType:
+		// anonymous structs have no methods; their fields are validated inline
+		sb.WriteString(r.generateInlineStruct(nested, t, fieldName, oldName, path))
+
+	case *ast.SelectorExpr, *ast.Ident:
+		kind := r.loader.validatorKind(nested.file, t)
+		if kind == noValidator {
+			break
+		}
+		// types implementing validation.Validator are validated with Validate()
+		call := fmt.Sprintf("%s.Validate()", fieldName)
+		closing := ""
+		switch {
+		case kind == generatedValidator && (nested.mode == validateCreate || oldName == ""):
+			call = fmt.Sprintf("%s.ValidateContext(ctx, opts.Nested(errs))", fieldName)
+		case kind == generatedValidator:
+			call = fmt.Sprintf("%s.%s(ctx, %s, opts.Nested(errs))", fieldName, nested.mode.method(), oldName)
+		case nested.mode == validateRatcheting && oldName != "":
+			// the element of a container is only validated when it changed
+			value := "&" + fieldName
+			if nested.pointer {
+				value = fieldName
+			}
+			changed := r.changed(nested.file, &ast.StarExpr{X: t}, value, oldName, nested.imports)
+			sb.WriteString(fmt.Sprintf("if %s {\n", changed))
+			closing = "}\n"
+		}
+		if kind == externalValidator {
+			call = r.externalCall(nested, t, fieldName)
+		}
+		sb.WriteString(fmt.Sprintf("if err := %s; err != nil {\n", call))
+		sb.WriteString(fmt.Sprintf("\terrs = errors.Join(errs, %s)\n", prefixed(path, "err")))
 		sb.WriteString("}\n")
+		sb.WriteString(closing)
 
 	case *ast.ArrayType:
 		// If it's an array/slice, iterate and call Validate()
-		iteratorVar := "item"
-		sb.WriteString(fmt.Sprintf("for _, %s := range %s {\n", iteratorVar, fieldName))
-		sb.WriteString(generateNestedStructs(fieldInfo, t.Elt, iteratorVar))
+		iteratorVar, indexVar, oldVar := nested.loopVar("item"), nested.loopVar("i"), nested.loopVar("oldItem")
+		itemOld := ""
+		if oldName != "" && r.usesOld(nested, t) {
+			itemOld = r.oldListElem(nested, t, derefOld(oldName), iteratorVar, indexVar)
+		}
+		sb.WriteString(fmt.Sprintf("for %s, %s := range %s {\n", indexVar, iteratorVar, fieldName))
+		sb.WriteString(contextCheck)
+		if itemOld != "" {
+			sb.WriteString(fmt.Sprintf("%s := %s\n", oldVar, itemOld))
+			itemOld = oldVar
+		}
+		nested.listKeys = nil
+		nested.depth++
+		nested.pointer = false
+		sb.WriteString(r.generateNestedStructs(nested, t.Elt, iteratorVar, itemOld, fmt.Sprintf("validation.Index(%s, %s)", path, indexVar)))
+		sb.WriteString(exceededCheck)
 		sb.WriteString("}\n")
 
 	case *ast.MapType:
-		// If it's a map, iterate over values and call Validate()
-		iteratorVar := "value"
-		sb.WriteString(fmt.Sprintf("for _, %s := range %s {\n", iteratorVar, fieldName))
-		sb.WriteString(generateNestedStructs(fieldInfo, t.Value, iteratorVar))
+		// If it's a map, iterate over the values in the order of their keys
+		iteratorVar, keyVar := nested.loopVar("value"), nested.loopVar("k")
+		valueOld := ""
+		if oldName != "" && r.usesOld(nested, t) {
+			oldMap := derefOld(oldName)
+			// map values are correlated by key
+			valueOld = fmt.Sprintf("validation.MapElemAt(%s, %s)", oldMap, keyVar)
+			if _, ok := t.Value.(*ast.StarExpr); ok {
+				valueOld = fmt.Sprintf("validation.MapPointerAt(%s, %s)", oldMap, keyVar)
+			}
+		}
+		sortedKeys := "validation.SortedKeys"
+		if !r.loader.isOrdered(nested.file, t.Key) {
+			sortedKeys = "validation.SortedKeysFunc"
+		}
+		sb.WriteString(fmt.Sprintf("for _, %s := range %s(%s) {\n", keyVar, sortedKeys, fieldName))
+		sb.WriteString(contextCheck)
+		sb.WriteString(fmt.Sprintf("%s := %s[%s]\n", iteratorVar, fieldName, keyVar))
+		nested.listKeys = nil
+		nested.depth++
+		nested.pointer = false
+		sb.WriteString(r.generateNestedStructs(nested, t.Value, iteratorVar, valueOld, fmt.Sprintf("validation.Key(%s, %s)", path, keyVar)))
+		sb.WriteString(exceededCheck)
 		sb.WriteString("}\n")
 	}
 
 	return sb.String()
 }
 
-// isIdentDeclaredAsStruct check if an `ast.Ident` is a Struct
-func isDeclaredAsStructOrEnum(typeName string, node *ast.File) bool {
-	for _, decl := range node.Decls {
-		if genDecl, ok := decl.(*ast.GenDecl); ok {
-			for _, spec := range genDecl.Specs {
-				if typeSpec, ok := spec.(*ast.TypeSpec); ok {
-					if typeSpec.Name.Name == typeName {
-						_, isStruct := typeSpec.Type.(*ast.StructType)
-						_, isEnum := detectTypeAlias(node, typeName)
-						return isStruct || isEnum
-					}
-				}
-			}
+// usesOld returns true if the generated validation of a value of type expr
+// refers to the old value.
+func (r *Generator) usesOld(nested nestedValidation, expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return r.usesOld(nested, t.X)
+	case *ast.ArrayType:
+		if nested.mode == validateRatcheting && len(nested.listKeys) == 0 {
+			// the elements are not correlated
+			return false
+		}
+		nested.listKeys = nil
+		return r.usesOld(nested, t.Elt)
+	case *ast.MapType:
+		nested.listKeys = nil
+		return r.usesOld(nested, t.Value)
+	case *ast.StructType:
+		return true
+	}
+	return nested.mode == validateRatcheting || r.loader.isValidatedStruct(nested.file, expr)
+}
+
+// prefixed returns the Go expression of the error err reported under the
+// path the Go expression path evaluates to. The errors of inlined embedded
+// structs keep their path.
+func prefixed(path, err string) string {
+	if path == `""` {
+		return err
+	}
+	return fmt.Sprintf("validation.Prefix(%s, %s)", path, err)
+}
+
+// oldVar matches the references to the old value in generated code.
+var oldVar = regexp.MustCompile(`\bold\.`)
+
+// generateInlineStruct generates the validation of the nested value
+// fieldName of an anonymous struct type. The fields are validated by a
+// function literal shadowing the receiver and the old value, so their checks
+// are generated like the checks of a named struct.
+func (r *Generator) generateInlineStruct(nested nestedValidation, st *ast.StructType, fieldName, oldName, path string) string {
+	info, err := r.processStruct(nested.file, "", st)
+	if err != nil {
+		// reported by processFile
+		return ""
+	}
+	mode := nested.mode
+	if oldName == "" {
+		mode = validateCreate
+	}
+	if !info.validates(mode) {
+		return ""
+	}
+	value := "&" + fieldName
+	if nested.pointer {
+		value = fieldName
+	}
+	body := r.generateStructValidation(&FileInfo{File: nested.file}, info, mode, nested.imports)
+
+	var sb strings.Builder
+	sb.WriteString("if err := func() error {\n")
+	sb.WriteString(fmt.Sprintf("r := %s\n", value))
+	sb.WriteString("opts := opts.Nested(errs)\n")
+	if mode != validateCreate && oldVar.MatchString(body) {
+		sb.WriteString(fmt.Sprintf("old := %s\n", strings.TrimPrefix(oldName, "&")))
+		if !strings.HasPrefix(oldName, "&") {
+			// values without old value are validated on their own
+			sb.WriteString("if old == nil {\n")
+			sb.WriteString(r.generateStructValidation(&FileInfo{File: nested.file}, info, validateCreate, nested.imports))
+			sb.WriteString("}\n")
 		}
 	}
+	sb.WriteString(body)
+	sb.WriteString("}(); err != nil {\n")
+	sb.WriteString(fmt.Sprintf("\terrs = errors.Join(errs, %s)\n", prefixed(path, "err")))
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// externalCall returns the call of the validator registered for the type
+// expr with the nested value, and records the package of the validator.
+func (r *Generator) externalCall(nested nestedValidation, expr ast.Expr, value string) string {
+	v, _ := r.loader.external(nested.file, expr)
+	if nested.pointer {
+		value = "*" + value
+	}
+	if v.ImportPath == nested.file.ImportPath {
+		return fmt.Sprintf("%s(%s)", v.Func, value)
+	}
+	name := r.loader.packageName(nested.file, v.ImportPath)
+	nested.imports[v.ImportPath] = ""
+	if name != v.ImportPath[strings.LastIndex(v.ImportPath, "/")+1:] {
+		nested.imports[v.ImportPath] = name
+	}
+	return fmt.Sprintf("%s.%s(%s)", name, v.Func, value)
+}
+
+// derefOld returns the Go expression of the old value oldName points to. A
+// pointer to a field is dereferenced directly, other pointers may be nil.
+func derefOld(oldName string) string {
+	if field, ok := strings.CutPrefix(oldName, "&"); ok {
+		return field
+	}
+	return fmt.Sprintf("validation.Deref(%s)", oldName)
+}
+
+// oldListElem returns the Go expression pointing to the element of the old
+// list oldList that the element item is validated against, or an empty
+// string if the elements are not correlated. Updates correlate elements by
+// index. Ratcheting correlates the elements of lists of type map by key and
+// treats other lists as atomic, like Kubernetes does.
+func (r *Generator) oldListElem(nested nestedValidation, t *ast.ArrayType, oldList, item, index string) string {
+	_, pointer := t.Elt.(*ast.StarExpr)
+	at := "validation.ElemAt"
+	if pointer {
+		at = "validation.PointerAt"
+	}
+	switch nested.mode {
+	case validateUpdate:
+		return fmt.Sprintf("%s(%s, %s)", at, oldList, index)
+	case validateRatcheting:
+		if len(nested.listKeys) == 0 {
+			return ""
+		}
+		var match []string
+		if pointer {
+			match = append(match, fmt.Sprintf("%s[j] != nil", oldList))
+		}
+		for _, key := range nested.listKeys {
+			match = append(match, fmt.Sprintf("%s[j].%s == %s.%s", oldList, key, item, key))
+		}
+		return fmt.Sprintf("%s(%s, validation.IndexOf(len(%s), func(j int) bool { return %s }))", at, oldList, oldList, strings.Join(match, " && "))
+	}
+	return ""
+}
+
+// isContainer returns true if expr is a list or map type, or a pointer to
+// one.
+func isContainer(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return isContainer(t.X)
+	case *ast.ArrayType, *ast.MapType:
+		return true
+	}
 	return false
 }
 
+// isAnonymousStruct returns true if expr is an anonymous struct type, or a
+// pointer to one.
+func isAnonymousStruct(expr ast.Expr) bool {
+	_, ok := derefType(expr).(*ast.StructType)
+	return ok
+}
+
+// derefType returns the type a pointer type points to, or expr itself.
+func derefType(expr ast.Expr) ast.Expr {
+	if t, ok := expr.(*ast.StarExpr); ok {
+		return t.X
+	}
+	return expr
+}
+
+// elemType returns the element type of a list type, or nil.
+func elemType(expr ast.Expr) ast.Expr {
+	if t, ok := expr.(*ast.ArrayType); ok {
+		return t.Elt
+	}
+	return nil
+}
+
 // detectTypeAlias checks if a type is an alias of string.
 func detectTypeAlias(node ast.Node, typeName string) (string, bool) {
 	found := false
@@ -403,30 +1373,41 @@ func detectTypeAlias(node ast.Node, typeName string) (string, bool) {
 	return baseType, found
 }
 
-// generateEnumValidation generates an enum validation function
-func generateEnumValidation(fieldName, typeName string, allowedValues []string) string {
-	if len(allowedValues) == 0 {
-		return ""
-	}
+// generateEnumValidation generates the body of the Validate method of a
+// named scalar type, which checks the rules of the type and, for enums, that
+// the value is one of the constants of the type.
+func generateEnumValidation(enumInfo EnumInfo) string {
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("\tvalid := map[%s]struct{}{", typeName))
-	for _, v := range allowedValues {
-		sb.WriteString(fmt.Sprintf("\t%s: {}, ", v))
-	}
-	sb.WriteString("}\n")
-
-	// Generate validation check
-	v := "s"
-	if strings.HasPrefix(typeName, "int") || strings.HasPrefix(typeName, "uint") {
-		v = "d"
+	if len(enumInfo.Rules) > 0 {
+		sb.WriteString("var errs error\n")
+		for _, rule := range enumInfo.Rules {
+			sb.WriteString(rule.ExpandCode(`""`, "r"))
+		}
 	}
-	sb.WriteString(fmt.Sprintf(
-		`if _, ok := valid[%s(r)]; !ok {
-    		return fmt.Errorf("invalid value for %s: %%%s", r)
+	if len(enumInfo.AllowedValues) > 0 {
+		sb.WriteString(fmt.Sprintf("\tvalid := map[%s]struct{}{", enumInfo.Type))
+		for _, v := range enumInfo.AllowedValues {
+			sb.WriteString(fmt.Sprintf("\t%s: {}, ", v))
 		}
-		return nil`,
-		typeName, fieldName, v))
+		sb.WriteString("}\n")
 
+		// Generate validation check
+		allowed := strings.ReplaceAll(strings.Join(enumInfo.AllowedValues, ", "), "{", "{{")
+		invalid := fmt.Sprintf(`validation.Invalid("", validation.ErrEnum, "enum", %s, validation.Args{"value": r})`,
+			strconv.Quote(enumInfo.Name+" must be one of "+allowed+`, got "{value}"`))
+		sb.WriteString(fmt.Sprintf("if _, ok := valid[%s(r)]; !ok {\n", enumInfo.Type))
+		if len(enumInfo.Rules) > 0 {
+			sb.WriteString(fmt.Sprintf("errs = errors.Join(errs, %s)\n", invalid))
+		} else {
+			sb.WriteString(fmt.Sprintf("return %s\n", invalid))
+		}
+		sb.WriteString("}\n")
+	}
+	if len(enumInfo.Rules) > 0 {
+		sb.WriteString("return errs\n")
+	} else {
+		sb.WriteString("return nil\n")
+	}
 	return sb.String()
 }
 
@@ -498,8 +1479,7 @@ func formatGoFile(filename string) {
 	}
 }
 
-
 func isPointerType(expr ast.Expr) bool {
 	_, ok := expr.(*ast.StarExpr)
 	return ok
-}
\ No newline at end of file
+}
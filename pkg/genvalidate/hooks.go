@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The hooks of a struct are hand-written methods with signature
+// `func() error` the generated validation calls before or after the rules of
+// the fields, e.g. `// +validate(before=checkNames)`. The methods
+// ValidateBefore and ValidateAfter are hooks without marker.
+const (
+	beforeHook = "before"
+	afterHook  = "after"
+)
+
+// hooks returns the methods of the struct type decl called before and after
+// the rules of its fields, in call order: ValidateBefore and ValidateAfter
+// first, then the methods named by the markers in the order of the markers.
+func (r *Loader) hooks(decl *TypeDecl) (before, after []string, err error) {
+	if r.hasErrorMethod(decl, "ValidateBefore") {
+		before = append(before, "ValidateBefore")
+	}
+	if r.hasErrorMethod(decl, "ValidateAfter") {
+		after = append(after, "ValidateAfter")
+	}
+	doc := decl.Doc()
+	if doc == nil {
+		return before, after, nil
+	}
+	for _, comment := range doc.List {
+		when, name, ok := parseHook(comment.Text)
+		if !ok {
+			continue
+		}
+		if !r.hasErrorMethod(decl, name) {
+			return nil, nil, fmt.Errorf("invalid hook of type %s: no method %s with signature func() error", decl.Name, name)
+		}
+		if when == beforeHook {
+			before = append(before, name)
+		} else {
+			after = append(after, name)
+		}
+	}
+	return before, after, nil
+}
+
+// parseHook parses a hook marker, e.g. `// +validate(before=checkNames)`,
+// and returns when the hook is called and the name of its method.
+func parseHook(comment string) (when, name string, ok bool) {
+	text := strings.TrimSpace(comment)
+	if !strings.HasPrefix(text, "// +validate(") || !strings.HasSuffix(text, ")") {
+		return "", "", false
+	}
+	when, name, ok = strings.Cut(text[len("// +validate("):len(text)-1], "=")
+	when, name = strings.TrimSpace(when), strings.TrimSpace(name)
+	if !ok || (when != beforeHook && when != afterHook) {
+		return "", "", false
+	}
+	return when, name, true
+}
+
+// generateHooks generates the calls of the hooks of the receiver r, whose
+// errors are merged with the errors of the rules.
+func generateHooks(hooks []string) string {
+	var sb strings.Builder
+	for _, hook := range hooks {
+		sb.WriteString(fmt.Sprintf("errs = errors.Join(errs, r.%s())\n", hook))
+		sb.WriteString(exceededCheck)
+	}
+	return sb.String()
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const generatedHeader = "// GENERATED CODE - DO NOT EDIT"
+
+// A Loader parses the Go files below a root directory and indexes the type
+// declarations they contain, so the generators can resolve field types across
+// the packages of the tree.
+type Loader struct {
+	root      string
+	module    string
+	moduleDir string
+	fset      *token.FileSet
+	files     []*File
+	types     map[string]*TypeDecl
+	// methods indexes the hand-written methods by receiver type and name.
+	methods map[string]map[string]*ast.FuncDecl
+	// foreign caches the type-checked packages outside the tree.
+	foreign   map[string]*types.Package
+	externals map[string]ExternalValidator
+}
+
+// A File is a parsed, hand-written Go file of the tree.
+type File struct {
+	Path       string
+	Package    string
+	ImportPath string
+	Node       *ast.File
+	// Imports maps the name a package is referenced by in the file to its
+	// import path.
+	Imports map[string]string
+}
+
+// A TypeDecl is a type declared in one of the files of the tree.
+type TypeDecl struct {
+	Name    string
+	File    *File
+	GenDecl *ast.GenDecl
+	Spec    *ast.TypeSpec
+}
+
+func NewLoader(root string) *Loader {
+	return &Loader{
+		root:      root,
+		fset:      token.NewFileSet(),
+		types:     map[string]*TypeDecl{},
+		methods:   map[string]map[string]*ast.FuncDecl{},
+		foreign:   map[string]*types.Package{},
+		externals: map[string]ExternalValidator{},
+	}
+}
+
+// Load parses all hand-written Go files below the root directory. Files
+// produced by the generators are skipped.
+func (r *Loader) Load() error {
+	absRoot, err := filepath.Abs(r.root)
+	if err != nil {
+		return err
+	}
+	if err := r.findModule(absRoot); err != nil {
+		return err
+	}
+	return filepath.Walk(r.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		node, err := parser.ParseFile(r.fset, path, nil, parser.AllErrors|parser.ParseComments)
+		if err != nil {
+			fmt.Println("Error processing", path, ":", err)
+			return nil
+		}
+		if isGeneratedFile(node) {
+			return nil
+		}
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(r.moduleDir, filepath.Dir(absPath))
+		if err != nil {
+			return err
+		}
+		file := &File{
+			Path:       path,
+			Package:    node.Name.Name,
+			ImportPath: r.module,
+			Node:       node,
+			Imports:    map[string]string{},
+		}
+		if rel != "." {
+			file.ImportPath = r.module + "/" + filepath.ToSlash(rel)
+		}
+		for _, imp := range node.Imports {
+			importPath, _ := strconv.Unquote(imp.Path.Value)
+			name := importPath[strings.LastIndex(importPath, "/")+1:]
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			file.Imports[name] = importPath
+		}
+		r.files = append(r.files, file)
+		r.indexTypes(file)
+		r.indexExternals(file)
+		return nil
+	})
+}
+
+// Files returns the loaded files in lexical order of their path.
+func (r *Loader) Files() []*File {
+	sort.SliceStable(r.files, func(i, j int) bool { return r.files[i].Path < r.files[j].Path })
+	return r.files
+}
+
+// FileTypes returns the type declarations of the file in source order.
+func (r *Loader) FileTypes(file *File) []*TypeDecl {
+	var decls []*TypeDecl
+	for _, decl := range file.Node.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+				decls = append(decls, r.Lookup(file.ImportPath, typeSpec.Name.Name))
+			}
+		}
+	}
+	return decls
+}
+
+// Lookup returns the type declaration of the named type in the package with
+// the given import path, or nil if the type is not declared in the tree.
+func (r *Loader) Lookup(importPath, name string) *TypeDecl {
+	return r.types[importPath+"."+name]
+}
+
+// Resolve returns the declaration of the named type referenced by expr in the
+// given file, or nil if expr is not a named type declared in the tree.
+func (r *Loader) Resolve(file *File, expr ast.Expr) *TypeDecl {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return r.Lookup(file.ImportPath, t.Name)
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		importPath, ok := file.Imports[pkg.Name]
+		if !ok {
+			return nil
+		}
+		return r.Lookup(importPath, t.Sel.Name)
+	}
+	return nil
+}
+
+// packageName returns the name the package with the given import path is
+// referenced by in the file: the name the file imports it with, or else the
+// name of the package.
+func (r *Loader) packageName(file *File, importPath string) string {
+	for name, path := range file.Imports {
+		if path == importPath {
+			return name
+		}
+	}
+	for _, f := range r.files {
+		if f.ImportPath == importPath {
+			return f.Package
+		}
+	}
+	return importPath[strings.LastIndex(importPath, "/")+1:]
+}
+
+func (r *Loader) indexTypes(file *File) {
+	for _, decl := range file.Node.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv != nil {
+			recv := derefType(funcDecl.Recv.List[0].Type)
+			if ident, ok := recv.(*ast.Ident); ok {
+				key := file.ImportPath + "." + ident.Name
+				if r.methods[key] == nil {
+					r.methods[key] = map[string]*ast.FuncDecl{}
+				}
+				r.methods[key][funcDecl.Name.Name] = funcDecl
+			}
+			continue
+		}
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			r.types[file.ImportPath+"."+typeSpec.Name.Name] = &TypeDecl{
+				Name:    typeSpec.Name.Name,
+				File:    file,
+				GenDecl: genDecl,
+				Spec:    typeSpec,
+			}
+		}
+	}
+}
+
+// findModule looks up the go.mod file enclosing dir and records the module
+// path and directory.
+func (r *Loader) findModule(dir string) error {
+	for d := dir; ; d = filepath.Dir(d) {
+		f, err := os.Open(filepath.Join(d, "go.mod"))
+		if err == nil {
+			defer f.Close()
+			scanner := bufio.NewScanner(f)
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if strings.HasPrefix(line, "module ") {
+					r.module = strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+					r.moduleDir = d
+					return nil
+				}
+			}
+			return fmt.Errorf("no module declaration in %s", filepath.Join(d, "go.mod"))
+		}
+		if d == filepath.Dir(d) {
+			return fmt.Errorf("no go.mod found for %s", dir)
+		}
+	}
+}
+
+// Doc returns the comments documenting the type declaration.
+func (r *TypeDecl) Doc() *ast.CommentGroup {
+	if r.Spec.Doc != nil {
+		return r.Spec.Doc
+	}
+	return r.GenDecl.Doc
+}
+
+// HasMarker returns true if the type declaration is documented with a
+// comment starting with the given marker.
+func (r *TypeDecl) HasMarker(marker string) bool {
+	doc := r.Doc()
+	if doc == nil {
+		return false
+	}
+	for _, comment := range doc.List {
+		if strings.HasPrefix(strings.TrimSpace(comment.Text), marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func isGeneratedFile(node *ast.File) bool {
+	for _, group := range node.Comments {
+		if group.Pos() > node.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if strings.TrimSpace(comment.Text) == generatedHeader {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeImports writes the import declaration of a generated file. imports
+// maps the import paths to the name they are imported with, or to an empty
+// string for the default name. Standard library packages are grouped first.
+func writeImports(sb *strings.Builder, imports map[string]string) {
+	var std, other []string
+	for importPath := range imports {
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			other = append(other, importPath)
+		} else {
+			std = append(std, importPath)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	sb.WriteString("import (\n")
+	for i, group := range [][]string{std, other} {
+		if i > 0 && len(std) > 0 && len(other) > 0 {
+			sb.WriteString("\n")
+		}
+		for _, importPath := range group {
+			if name := imports[importPath]; name != "" {
+				sb.WriteString(fmt.Sprintf("\t%s %q\n", name, importPath))
+			} else {
+				sb.WriteString(fmt.Sprintf("\t%q\n", importPath))
+			}
+		}
+	}
+	sb.WriteString(")\n\n")
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strings"
+)
+
+// jsonName returns the name of the field in its JSON representation. inline
+// is true for embedded fields whose fields are inlined in the parent object.
+func jsonName(field *ast.Field) (name string, inline bool) {
+	goName := embeddedFieldName(field.Type)
+	if len(field.Names) > 0 {
+		goName = field.Names[0].Name
+	}
+	if field.Tag == nil {
+		return goName, len(field.Names) == 0
+	}
+	tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("json")
+	name, _, _ = strings.Cut(tag, ",")
+	switch {
+	case name == "" && len(field.Names) == 0:
+		return goName, true
+	case name == "" || name == "-":
+		return goName, false
+	}
+	return name, false
+}
+
+// fieldMarkers returns the values of the markers documenting the field with
+// the given name, e.g. `map` for `// +listType=map`. Both `=` and `:=` are
+// accepted as separator.
+func fieldMarkers(field *ast.Field, name string) []string {
+	if field.Doc == nil {
+		return nil
+	}
+	var values []string
+	for _, comment := range field.Doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		rest, ok := strings.CutPrefix(text, "+"+name)
+		if !ok {
+			continue
+		}
+		if value, ok := strings.CutPrefix(rest, ":="); ok {
+			values = append(values, strings.TrimSpace(value))
+		} else if value, ok := strings.CutPrefix(rest, "="); ok {
+			values = append(values, strings.TrimSpace(value))
+		}
+	}
+	return values
+}
+
+// hasFieldMarker returns true if the field is documented with the given
+// marker comment, e.g. `// +equal(skip)`.
+func hasFieldMarker(field *ast.Field, marker string) bool {
+	if field.Doc == nil {
+		return false
+	}
+	for _, comment := range field.Doc.List {
+		if strings.TrimSpace(comment.Text) == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// listMapKeys returns the JSON names of the keys of a list of type map, or
+// nil if the field is not a list of type map.
+func listMapKeys(field *ast.Field) []string {
+	listType := fieldMarkers(field, "listType")
+	if len(listType) == 0 || listType[len(listType)-1] != "map" {
+		return nil
+	}
+	return fieldMarkers(field, "listMapKey")
+}
+
+// structFieldByJSONName returns the Go name of the field of the struct type
+// with the given JSON name, looking into inlined embedded fields of the tree.
+func (r *Loader) structFieldByJSONName(decl *TypeDecl, name string) (string, bool) {
+	st, ok := decl.Spec.Type.(*ast.StructType)
+	if !ok {
+		return "", false
+	}
+	for _, field := range st.Fields.List {
+		fieldName, inline := jsonName(field)
+		if inline {
+			if embedded := r.Resolve(decl.File, field.Type); embedded != nil {
+				if goName, ok := r.structFieldByJSONName(embedded, name); ok {
+					return goName, true
+				}
+			}
+			continue
+		}
+		if fieldName == name {
+			return fieldNames(field)[0], true
+		}
+	}
+	return "", false
+}
+
+// structFieldType returns the file and the type of the field of the struct
+// type decl with the given Go name, including the fields promoted from
+// embedded structs.
+func (r *Loader) structFieldType(decl *TypeDecl, name string) (*File, ast.Expr, bool) {
+	st, ok := decl.Spec.Type.(*ast.StructType)
+	if !ok {
+		return nil, nil, false
+	}
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			if embedded := r.Resolve(decl.File, derefType(field.Type)); embedded != nil {
+				if file, typ, ok := r.structFieldType(embedded, name); ok {
+					return file, typ, true
+				}
+			}
+		}
+		for _, fieldName := range fieldNames(field) {
+			if fieldName == name {
+				return decl.File, field.Type, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// listMapKeyFields returns the Go names of the key fields of a list of type
+// map with elements of type elt.
+func (r *Loader) listMapKeyFields(file *File, field *ast.Field, elt ast.Expr) ([]string, bool) {
+	keys := listMapKeys(field)
+	decl := r.Resolve(file, elt)
+	if len(keys) == 0 || decl == nil {
+		return nil, false
+	}
+	var fields []string
+	for _, key := range keys {
+		goName, ok := r.structFieldByJSONName(decl, key)
+		if !ok {
+			fmt.Printf("Ignoring listType=map of %s: unknown key %s\n", fieldNames(field)[0], key)
+			return nil, false
+		}
+		fields = append(fields, goName)
+	}
+	return fields, true
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/types"
+	"strings"
+)
+
+// externalMarker registers the validator of a type that cannot be annotated,
+// e.g. `// +godantic:external(type=time.Time, func=ValidateTime)`. func
+// defaults to the function the marker documents, and may be qualified with
+// the name of a package imported by the file.
+const externalMarker = "// +godantic:external("
+
+// validatorKind defines how the values of a type are validated.
+type validatorKind int
+
+const (
+	// noValidator types are not validated.
+	noValidator validatorKind = iota
+	// generatedValidator types are structs of the tree with generated
+	// ValidateContext methods.
+	generatedValidator
+	// methodValidator types implement the validation.Validator interface.
+	methodValidator
+	// externalValidator types are validated by the function registered for
+	// the type.
+	externalValidator
+)
+
+// An ExternalValidator is a function validating the values of a type that
+// cannot have a Validate method, e.g. a type of another module.
+type ExternalValidator struct {
+	// ImportPath is the import path of the package of the function.
+	ImportPath string
+	// Func is the name of the function, with signature `func(T) error`.
+	Func string
+}
+
+// RegisterValidator registers the function validating the values of the type
+// with the given qualified name, e.g. `time.Time`.
+func (r *Loader) RegisterValidator(typeName string, v ExternalValidator) {
+	r.externals[typeName] = v
+}
+
+// indexExternals registers the validators declared with external markers in
+// the file.
+func (r *Loader) indexExternals(file *File) {
+	// the function documented by each comment group
+	documented := map[*ast.CommentGroup]string{}
+	for _, decl := range file.Node.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv == nil && funcDecl.Doc != nil {
+			documented[funcDecl.Doc] = funcDecl.Name.Name
+		}
+	}
+	for _, group := range file.Node.Comments {
+		for _, comment := range group.List {
+			text := strings.TrimSpace(comment.Text)
+			if !strings.HasPrefix(text, externalMarker) {
+				continue
+			}
+			typeName, v, err := r.parseExternal(file, text, documented[group])
+			if err != nil {
+				fmt.Println("Error processing", file.Path, ":", err)
+				continue
+			}
+			if prev, ok := r.externals[typeName]; ok && prev != v {
+				fmt.Println("Error processing", file.Path, ": validator of", typeName, "registered twice")
+				continue
+			}
+			r.RegisterValidator(typeName, v)
+		}
+	}
+}
+
+// parseExternal parses an external marker of the file, given the name of the
+// function it documents, if any. It returns the qualified name of the type
+// and its validator.
+func (r *Loader) parseExternal(file *File, marker, documented string) (string, ExternalValidator, error) {
+	if !strings.HasSuffix(marker, ")") {
+		return "", ExternalValidator{}, fmt.Errorf("invalid external syntax got: %s", marker)
+	}
+	attrs := map[string]string{}
+	for _, attr := range splitTopLevel(strings.TrimSuffix(strings.TrimPrefix(marker, externalMarker), ")")) {
+		key, value, ok := strings.Cut(attr, "=")
+		if !ok {
+			return "", ExternalValidator{}, fmt.Errorf("invalid external attribute %q got: %s", attr, marker)
+		}
+		attrs[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	typeName, ok := r.qualify(file, attrs["type"])
+	if !ok {
+		return "", ExternalValidator{}, fmt.Errorf("invalid external type %q got: %s", attrs["type"], marker)
+	}
+	v := ExternalValidator{ImportPath: file.ImportPath, Func: attrs["func"]}
+	if v.Func == "" {
+		v.Func = documented
+	}
+	if i := strings.LastIndex(v.Func, "."); i >= 0 {
+		importPath, ok := file.Imports[v.Func[:i]]
+		if !ok {
+			return "", ExternalValidator{}, fmt.Errorf("unknown package of external func %q got: %s", v.Func, marker)
+		}
+		v.ImportPath, v.Func = importPath, v.Func[i+1:]
+	}
+	if v.Func == "" {
+		return "", ExternalValidator{}, fmt.Errorf("missing external func got: %s", marker)
+	}
+	return typeName, v, nil
+}
+
+// qualify returns the name of the type referenced by name in the file,
+// qualified with the import path of its package, e.g. time.Time. A package
+// the file does not import is referenced by its import path.
+func (r *Loader) qualify(file *File, name string) (string, bool) {
+	i := strings.LastIndex(name, ".")
+	if i <= 0 || i == len(name)-1 {
+		return "", false
+	}
+	if importPath, ok := file.Imports[name[:i]]; ok {
+		return importPath + name[i:], true
+	}
+	return name, true
+}
+
+// external returns the function registered for the type referenced by expr.
+func (r *Loader) external(file *File, expr ast.Expr) (ExternalValidator, bool) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ExternalValidator{}, false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ExternalValidator{}, false
+	}
+	importPath, ok := file.Imports[pkg.Name]
+	if !ok {
+		return ExternalValidator{}, false
+	}
+	v, ok := r.externals[importPath+"."+sel.Sel.Name]
+	return v, ok
+}
+
+// validatorKind returns how the values of the named type referenced by expr
+// are validated. Types of the tree with the validation marker get generated
+// methods, other types are validated when their method set has a
+// `Validate() error` method or when a validator is registered for them.
+func (r *Loader) validatorKind(file *File, expr ast.Expr) validatorKind {
+	if _, ok := r.external(file, expr); ok {
+		return externalValidator
+	}
+	if decl := r.Resolve(file, expr); decl != nil {
+		if decl.HasMarker(validationMarker) {
+			switch decl.Spec.Type.(type) {
+			case *ast.StructType:
+				return generatedValidator
+			case *ast.Ident:
+				// enums get a generated Validate method
+				return methodValidator
+			}
+		}
+		if r.hasErrorMethod(decl, "Validate") {
+			return methodValidator
+		}
+		return noValidator
+	}
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		if pkg, ok := sel.X.(*ast.Ident); ok {
+			if importPath, ok := file.Imports[pkg.Name]; ok && r.foreignHasValidateMethod(importPath, sel.Sel.Name) {
+				return methodValidator
+			}
+		}
+	}
+	return noValidator
+}
+
+// needsValidation returns true if the values of type expr, or the elements
+// of a list or map of type expr, are validated.
+func (r *Loader) needsValidation(file *File, expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return r.needsValidation(file, t.X)
+	case *ast.ArrayType:
+		return r.needsValidation(file, t.Elt)
+	case *ast.MapType:
+		return r.needsValidation(file, t.Value)
+	case *ast.Ident, *ast.SelectorExpr:
+		return r.validatorKind(file, expr) != noValidator
+	}
+	return false
+}
+
+// hasErrorMethod returns true if a hand-written method with the given name
+// and signature `func() error` is declared for the type, e.g. Validate.
+func (r *Loader) hasErrorMethod(decl *TypeDecl, name string) bool {
+	method, ok := r.methods[decl.File.ImportPath+"."+decl.Name][name]
+	if !ok || method.Type.Params.NumFields() != 0 || method.Type.Results.NumFields() != 1 {
+		return false
+	}
+	result, ok := method.Type.Results.List[0].Type.(*ast.Ident)
+	return ok && result.Name == "error"
+}
+
+// foreignHasValidateMethod returns true if the method set of the type, or
+// of a pointer to the type, of a package outside the tree has a
+// `Validate() error` method.
+func (r *Loader) foreignHasValidateMethod(importPath, name string) bool {
+	sig := r.foreignMethod(importPath, name, "Validate")
+	errorType := types.Universe.Lookup("error").Type()
+	return sig != nil && sig.Params().Len() == 0 && sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), errorType)
+}
+
+// foreignMethod returns the signature of the method with the given name in
+// the method set of the type, or of a pointer to the type, of a package
+// outside the tree, or nil. The package is type-checked from source.
+func (r *Loader) foreignMethod(importPath, name, method string) *types.Signature {
+	typ := r.foreignType(importPath, name)
+	if typ == nil {
+		return nil
+	}
+	pkg := r.foreign[importPath]
+	for _, t := range []types.Type{typ, types.NewPointer(typ)} {
+		if sel := types.NewMethodSet(t).Lookup(pkg, method); sel != nil {
+			return sel.Type().(*types.Signature)
+		}
+	}
+	return nil
+}
+
+// foreignType returns the named type of a package outside the tree, or nil.
+// The package is type-checked from source.
+func (r *Loader) foreignType(importPath, name string) types.Type {
+	pkg, ok := r.foreign[importPath]
+	if !ok {
+		var err error
+		imp := importer.ForCompiler(r.fset, "source", nil).(types.ImporterFrom)
+		pkg, err = imp.ImportFrom(importPath, r.moduleDir, 0)
+		if err != nil {
+			fmt.Println("Error importing", importPath, ":", err)
+		}
+		r.foreign[importPath] = pkg
+	}
+	if pkg == nil {
+		return nil
+	}
+	typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	return typeName.Type()
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"os"
+	"strings"
+)
+
+// transformMarker normalizes the value of a field before it is validated,
+// e.g. `// +transform(trim, lower)`. The transforms are applied in order:
+//
+//   - trim and lower apply to strings and lists of strings;
+//   - sort sorts a list of scalars, or a list of structs by the keys of a
+//     list of type map or the given keys, e.g. `sort(keys=[type])`;
+//   - dedupe removes the repeated elements of a list of scalars;
+//   - default_if_empty sets an unset or empty scalar to the given value, e.g.
+//     `default_if_empty(value=enable)`.
+const transformMarker = "// +transform("
+
+// A transform is a parsed transform of a field, e.g. `sort(keys=[type])`.
+type transform struct {
+	Name  string
+	Attrs map[string]string
+}
+
+// A NormalizeGenerator generates Normalize and NormalizeAndValidate methods
+// for the validated struct types with transforms in their fields or nested
+// values.
+type NormalizeGenerator struct {
+	loader *Loader
+	// normalizes records per type if it has a Normalize method.
+	normalizes map[*TypeDecl]bool
+}
+
+func NewNormalizeGenerator(loader *Loader) *NormalizeGenerator {
+	return &NormalizeGenerator{
+		loader:     loader,
+		normalizes: map[*TypeDecl]bool{},
+	}
+}
+
+func (r *NormalizeGenerator) Generate() error {
+	for _, file := range r.loader.Files() {
+		imports := map[string]string{}
+		var body strings.Builder
+		for _, decl := range r.loader.FileTypes(file) {
+			if !r.hasNormalize(decl) {
+				continue
+			}
+			code, err := r.generateNormalize(decl, imports)
+			if err != nil {
+				return err
+			}
+			body.WriteString(code)
+		}
+		if body.Len() > 0 {
+			imports[validationImportPath] = ""
+			r.generateNormalizeCode(file, body.String(), imports)
+		}
+	}
+	return nil
+}
+
+func (r *NormalizeGenerator) generateNormalizeCode(file *File, body string, imports map[string]string) {
+	outputFile := strings.TrimSuffix(file.Path, ".go") + "_normalize.go"
+
+	var sb strings.Builder
+	sb.WriteString(generatedHeader + "\n")
+	sb.WriteString(fmt.Sprintf("package %s\n\n", file.Package))
+	writeImports(&sb, imports)
+	sb.WriteString(body)
+
+	if err := os.WriteFile(outputFile, []byte(sb.String()), 0644); err != nil {
+		fmt.Println("Error writing normalize file:", err)
+		return
+	}
+	formatGoFile(outputFile)
+	fmt.Println("Generated normalize file:", outputFile)
+}
+
+// hasNormalize returns true if decl is a validated struct type with
+// transforms in its fields or in the values of the validated struct types
+// it refers to.
+func (r *NormalizeGenerator) hasNormalize(decl *TypeDecl) bool {
+	if normalizes, ok := r.normalizes[decl]; ok {
+		return normalizes
+	}
+	normalizes := r.reachesTransforms(decl, map[*TypeDecl]bool{})
+	r.normalizes[decl] = normalizes
+	return normalizes
+}
+
+// reachesTransforms returns true if decl is a validated struct type with
+// transforms in its fields or in the validated struct types it refers to.
+// The types on the current path are visited, so recursive types terminate.
+func (r *NormalizeGenerator) reachesTransforms(decl *TypeDecl, visited map[*TypeDecl]bool) bool {
+	st, ok := decl.Spec.Type.(*ast.StructType)
+	if !ok || !decl.HasMarker(validationMarker) || visited[decl] {
+		return false
+	}
+	visited[decl] = true
+	for _, field := range st.Fields.List {
+		if hasTransforms(field) {
+			return true
+		}
+		if ref := r.referredType(decl.File, field.Type); ref != nil && r.reachesTransforms(ref, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// referredType returns the named type of the values of type expr, or of the
+// elements of a list or map of type expr, declared in the tree, or nil.
+func (r *NormalizeGenerator) referredType(file *File, expr ast.Expr) *TypeDecl {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return r.referredType(file, t.X)
+	case *ast.ArrayType:
+		return r.referredType(file, t.Elt)
+	case *ast.MapType:
+		return r.referredType(file, t.Value)
+	case *ast.Ident, *ast.SelectorExpr:
+		return r.loader.Resolve(file, expr)
+	}
+	return nil
+}
+
+// nestedNormalize returns the type of the nested values of type expr with a
+// Normalize method, or nil.
+func (r *NormalizeGenerator) nestedNormalize(file *File, expr ast.Expr) *TypeDecl {
+	if decl := r.referredType(file, expr); decl != nil && r.hasNormalize(decl) {
+		return decl
+	}
+	return nil
+}
+
+// generateNormalize generates the Normalize and NormalizeAndValidate methods
+// of the struct type decl.
+func (r *NormalizeGenerator) generateNormalize(decl *TypeDecl, imports map[string]string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("// Normalize applies the transforms of the fields of the receiver and of its\n")
+	sb.WriteString("// nested values.\n")
+	sb.WriteString(fmt.Sprintf("func (r *%s) Normalize() {\n", decl.Name))
+	sb.WriteString("r.NormalizeWith(validation.Options{})\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("// NormalizeWith normalizes the receiver like Normalize. The values of\n")
+	sb.WriteString("// recursive types are not normalized again within a cycle, nor nested deeper\n")
+	sb.WriteString("// than the maximum depth of the options.\n")
+	sb.WriteString(fmt.Sprintf("func (r *%s) NormalizeWith(opts validation.Options) {\n", decl.Name))
+	sb.WriteString("if r == nil {\nreturn\n}\n")
+	if r.loader.isRecursive(decl) {
+		sb.WriteString("// the type is recursive\n")
+		sb.WriteString("opts, err := opts.Enter(r)\n")
+		sb.WriteString("if err != nil {\nreturn\n}\n")
+	}
+	for _, field := range decl.Spec.Type.(*ast.StructType).Fields.List {
+		transforms, err := parseTransforms(field)
+		if err != nil {
+			return "", fmt.Errorf("invalid transform of type %s: %w", decl.Name, err)
+		}
+		for _, name := range fieldNames(field) {
+			if name == "" {
+				continue
+			}
+			value := "r." + name
+			for _, t := range transforms {
+				code, err := r.generateTransform(decl.File, field, t, value, imports)
+				if err != nil {
+					return "", fmt.Errorf("invalid transform %s of field %s.%s: %w", t.Name, decl.Name, name, err)
+				}
+				sb.WriteString(code)
+			}
+			sb.WriteString(r.generateNestedNormalize(decl.File, field.Type, value))
+		}
+	}
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("// NormalizeAndValidate normalizes the receiver and validates the result.\n")
+	sb.WriteString(fmt.Sprintf("func (r *%s) NormalizeAndValidate() error {\n", decl.Name))
+	sb.WriteString("r.Normalize()\n")
+	sb.WriteString("return r.Validate()\n")
+	sb.WriteString("}\n\n")
+	return sb.String(), nil
+}
+
+// generateTransform generates the statements applying the transform to the
+// value of the field.
+func (r *NormalizeGenerator) generateTransform(file *File, field *ast.Field, t transform, value string, imports map[string]string) (string, error) {
+	typ := field.Type
+	list, isList := typ.(*ast.ArrayType)
+	var elt ast.Expr
+	if isList {
+		elt = list.Elt
+	}
+	switch t.Name {
+	case "trim", "lower":
+		fn := map[string]string{"trim": "validation.TrimSpace", "lower": "validation.ToLower"}[t.Name]
+		switch {
+		case isList && r.loader.isString(file, elt):
+			return fmt.Sprintf("for i := range %s {\n%s[i] = %s(%s[i])\n}\n", value, value, fn, value), nil
+		case isPointerType(typ) && r.loader.isString(file, derefType(typ)):
+			return fmt.Sprintf("if %s != nil {\n*%s = %s(*%s)\n}\n", value, value, fn, value), nil
+		case r.loader.isString(file, typ):
+			return fmt.Sprintf("%s = %s(%s)\n", value, fn, value), nil
+		}
+		return "", fmt.Errorf("the field is not a string or a list of strings")
+	case "sort":
+		if !isList {
+			return "", fmt.Errorf("the field is not a list")
+		}
+		if _, ok := t.Attrs["keys"]; !ok && r.loader.isOrdered(file, elt) {
+			imports["slices"] = ""
+			return fmt.Sprintf("slices.Sort(%s)\n", value), nil
+		}
+		if isPointerType(elt) {
+			return "", fmt.Errorf("the elements are pointers")
+		}
+		keys, err := r.sortKeys(file, field, t)
+		if err != nil {
+			return "", err
+		}
+		var compares []string
+		for _, key := range keys {
+			compares = append(compares, fmt.Sprintf("cmp.Compare(%s[i].%s, %s[j].%s)", value, key, value, key))
+		}
+		compare := compares[0]
+		if len(compares) > 1 {
+			compare = fmt.Sprintf("cmp.Or(%s)", strings.Join(compares, ", "))
+		}
+		imports["cmp"] = ""
+		imports["sort"] = ""
+		return fmt.Sprintf("sort.SliceStable(%s, func(i, j int) bool {\nreturn %s < 0\n})\n", value, compare), nil
+	case "dedupe":
+		if !isList || !r.loader.isScalar(file, elt) {
+			return "", fmt.Errorf("the field is not a list of scalars")
+		}
+		return fmt.Sprintf("%s = validation.Dedupe(%s)\n", value, value), nil
+	case "default_if_empty":
+		v, ok := t.Attrs["value"]
+		if !ok {
+			return "", fmt.Errorf("default_if_empty requires a value")
+		}
+		if !r.loader.isScalar(file, derefType(typ)) {
+			return "", fmt.Errorf("the field is not a scalar")
+		}
+		if r.loader.isString(file, derefType(typ)) {
+			v = fmt.Sprintf("%q", strings.Trim(v, `"`))
+		}
+		if isPointerType(typ) {
+			return fmt.Sprintf("validation.DefaultPointer(&%s, %s)\n", value, v), nil
+		}
+		return fmt.Sprintf("validation.Default(&%s, %s)\n", value, v), nil
+	}
+	return "", fmt.Errorf("unsupported transform")
+}
+
+// sortKeys returns the Go names of the fields the elements of the list
+// field are sorted by: the given keys, or the keys of a list of type map.
+func (r *NormalizeGenerator) sortKeys(file *File, field *ast.Field, t transform) ([]string, error) {
+	elt := field.Type.(*ast.ArrayType).Elt
+	if value, ok := t.Attrs["keys"]; ok {
+		decl := r.loader.Resolve(file, elt)
+		if decl == nil {
+			return nil, fmt.Errorf("the elements are not structs")
+		}
+		var keys []string
+		for _, key := range strings.Split(strings.Trim(value, "[]"), ",") {
+			key = strings.TrimSpace(key)
+			if goName, ok := r.loader.structFieldByJSONName(decl, key); ok {
+				key = goName
+			}
+			keys = append(keys, key)
+		}
+		return keys, nil
+	}
+	if keys, ok := r.loader.listMapKeyFields(file, field, elt); ok {
+		return keys, nil
+	}
+	return nil, fmt.Errorf("sort requires keys for elements that are not scalars")
+}
+
+// generateNestedNormalize generates the statements normalizing the nested
+// values of type expr.
+func (r *NormalizeGenerator) generateNestedNormalize(file *File, expr ast.Expr, value string) string {
+	if r.nestedNormalize(file, expr) == nil {
+		return ""
+	}
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		// NormalizeWith accepts a nil receiver
+		return fmt.Sprintf("%s.NormalizeWith(opts)\n", value)
+	case *ast.ArrayType:
+		return fmt.Sprintf("for i := range %s {\n%s}\n", value, r.generateNestedNormalize(file, t.Elt, value+"[i]"))
+	case *ast.MapType:
+		if _, ok := t.Value.(*ast.StarExpr); ok {
+			return fmt.Sprintf("for _, v := range %s {\nv.NormalizeWith(opts)\n}\n", value)
+		}
+		// the values of a map are normalized as copies
+		return fmt.Sprintf("for k, v := range %s {\n%s%s[k] = v\n}\n", value, r.generateNestedNormalize(file, t.Value, "v"), value)
+	}
+	return fmt.Sprintf("%s.NormalizeWith(opts)\n", value)
+}
+
+// hasTransforms returns true if the field is documented with a transform
+// marker.
+func hasTransforms(field *ast.Field) bool {
+	if field.Doc == nil {
+		return false
+	}
+	for _, comment := range field.Doc.List {
+		if strings.HasPrefix(strings.TrimSpace(comment.Text), transformMarker) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTransforms returns the transforms of the field in the order of the
+// markers.
+func parseTransforms(field *ast.Field) ([]transform, error) {
+	if field.Doc == nil {
+		return nil, nil
+	}
+	var transforms []transform
+	for _, comment := range field.Doc.List {
+		text := strings.TrimSpace(comment.Text)
+		if !strings.HasPrefix(text, transformMarker) || !strings.HasSuffix(text, ")") {
+			continue
+		}
+		for _, item := range splitTopLevel(text[len(transformMarker) : len(text)-1]) {
+			t := transform{Attrs: map[string]string{}}
+			name, attrs, ok := strings.Cut(item, "(")
+			t.Name = strings.TrimSpace(name)
+			if ok {
+				for _, attr := range splitTopLevel(strings.TrimSuffix(attrs, ")")) {
+					key, value, ok := strings.Cut(attr, "=")
+					if !ok {
+						return nil, fmt.Errorf("invalid attribute %q of transform %s", attr, t.Name)
+					}
+					t.Attrs[strings.TrimSpace(key)] = strings.TrimSpace(value)
+				}
+			}
+			transforms = append(transforms, t)
+		}
+	}
+	return transforms, nil
+}
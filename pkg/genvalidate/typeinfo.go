@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+var predeclaredScalars = map[string]bool{
+	"bool": true, "string": true, "byte": true, "rune": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+// isScalar returns true if values of type expr are compared with ==.
+func (r *Loader) isScalar(file *File, expr ast.Expr) bool {
+	if ident, ok := expr.(*ast.Ident); ok && predeclaredScalars[ident.Name] {
+		return true
+	}
+	if decl := r.Resolve(file, expr); decl != nil {
+		return r.isScalar(decl.File, decl.Spec.Type)
+	}
+	return false
+}
+
+// isOrdered returns true if values of type expr can be sorted.
+func (r *Loader) isOrdered(file *File, expr ast.Expr) bool {
+	if ident, ok := expr.(*ast.Ident); ok && predeclaredScalars[ident.Name] {
+		return ident.Name != "bool" && !strings.HasPrefix(ident.Name, "complex")
+	}
+	if decl := r.Resolve(file, expr); decl != nil {
+		return r.isOrdered(decl.File, decl.Spec.Type)
+	}
+	return false
+}
+
+// isComparable returns true if values of type expr can be compared with ==
+// and used as map keys. Interfaces are comparable, but may hold values that
+// are not.
+func (r *Loader) isComparable(file *File, expr ast.Expr) bool {
+	return r.comparable(file, expr, map[*TypeDecl]bool{})
+}
+
+func (r *Loader) comparable(file *File, expr ast.Expr, visited map[*TypeDecl]bool) bool {
+	switch t := expr.(type) {
+	case *ast.StarExpr, *ast.ChanType, *ast.InterfaceType:
+		return true
+	case *ast.MapType, *ast.FuncType:
+		return false
+	case *ast.ArrayType:
+		return t.Len != nil && r.comparable(file, t.Elt, visited)
+	case *ast.StructType:
+		for _, field := range t.Fields.List {
+			if !r.comparable(file, field.Type, visited) {
+				return false
+			}
+		}
+		return true
+	case *ast.Ident:
+		if t.Name == "any" || t.Name == "error" || predeclaredScalars[t.Name] {
+			return true
+		}
+	}
+	if decl := r.Resolve(file, expr); decl != nil {
+		if visited[decl] {
+			return true
+		}
+		visited[decl] = true
+		return r.comparable(decl.File, decl.Spec.Type, visited)
+	}
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		if pkg, ok := sel.X.(*ast.Ident); ok {
+			if importPath, ok := file.Imports[pkg.Name]; ok {
+				typ := r.foreignType(importPath, sel.Sel.Name)
+				return typ != nil && types.Comparable(typ)
+			}
+		}
+	}
+	return false
+}
+
+// isString returns true if the underlying type of expr is string.
+func (r *Loader) isString(file *File, expr ast.Expr) bool {
+	if ident, ok := expr.(*ast.Ident); ok && ident.Name == "string" {
+		return true
+	}
+	if decl := r.Resolve(file, expr); decl != nil {
+		return r.isString(decl.File, decl.Spec.Type)
+	}
+	return false
+}
+
+// isEqualer returns true if expr is a type with generated Equal and Diff
+// methods.
+func (r *Loader) isEqualer(file *File, expr ast.Expr) bool {
+	decl := r.Resolve(file, expr)
+	if decl == nil {
+		return false
+	}
+	_, ok := decl.Spec.Type.(*ast.StructType)
+	return ok && decl.HasMarker(equalMarker)
+}
+
+// isValueEqualer returns true if expr is a type with generated Equal and
+// Diff methods taking values, see equalValueMarker.
+func (r *Loader) isValueEqualer(file *File, expr ast.Expr) bool {
+	decl := r.Resolve(file, expr)
+	return decl != nil && decl.HasMarker(equalValueMarker)
+}
+
+// isValidatedStruct returns true if expr is a struct type with generated
+// Validate and ValidateUpdate methods.
+func (r *Loader) isValidatedStruct(file *File, expr ast.Expr) bool {
+	decl := r.Resolve(file, expr)
+	if decl == nil {
+		return false
+	}
+	_, ok := decl.Spec.Type.(*ast.StructType)
+	return ok && decl.HasMarker(validationMarker)
+}
+
+// isRecursive returns true if the values of the struct type decl can hold
+// values of the same type, directly or through other types of the tree.
+func (r *Loader) isRecursive(decl *TypeDecl) bool {
+	visited := map[*TypeDecl]bool{}
+	var refers func(file *File, expr ast.Expr) bool
+	refers = func(file *File, expr ast.Expr) bool {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			return refers(file, t.X)
+		case *ast.ArrayType:
+			return refers(file, t.Elt)
+		case *ast.MapType:
+			return refers(file, t.Key) || refers(file, t.Value)
+		case *ast.StructType:
+			for _, field := range t.Fields.List {
+				if refers(file, field.Type) {
+					return true
+				}
+			}
+		case *ast.Ident, *ast.SelectorExpr:
+			ref := r.Resolve(file, expr)
+			if ref == decl {
+				return true
+			}
+			if ref != nil && !visited[ref] {
+				visited[ref] = true
+				return refers(ref.File, ref.Spec.Type)
+			}
+		}
+		return false
+	}
+	return refers(decl.File, decl.Spec.Type)
+}
+
+// differs returns the Go expression that is true if the values a and b of
+// type expr differ. Values that are not scalars are compared with
+// validation.DeepEqual, which covers every field, including the fields the
+// generated Equal methods skip, and compares times by their instant.
+func (r *Loader) differs(file *File, expr ast.Expr, a, b string) string {
+	if star, ok := expr.(*ast.StarExpr); ok && r.isScalar(file, star.X) {
+		return fmt.Sprintf("!diff.PointerEqual(%s, %s)", a, b)
+	}
+	if r.isScalar(file, expr) {
+		return fmt.Sprintf("%s != %s", a, b)
+	}
+	return fmt.Sprintf("!validation.DeepEqual(%s, %s)", a, b)
+}
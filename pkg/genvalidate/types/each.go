@@ -0,0 +1,158 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Wrapper applies a rule to the elements of a list or to the keys or
+// values of a map. Wrappers nest, e.g. each(each(length(min = 1))) applies
+// the length rule to the elements of a list of lists.
+type Wrapper interface {
+	ValidationRule
+	// Inner returns the wrapped rule.
+	Inner() ValidationRule
+	// loop returns the header of the loop over the container value at the
+	// given nesting depth, and the path and the value of an element.
+	loop(path, value string, container Container, depth int) (header, elemPath, elemValue string)
+}
+
+// A ContainerRule is a rule on a list or map that depends on its elements.
+type ContainerRule interface {
+	ValidationRule
+	// ExpandContainer generates the code of the rule for the container.
+	ExpandContainer(fieldName, fieldNameCode string, container Container) string
+}
+
+// Container describes a list or map a wrapper ranges over.
+type Container struct {
+	// Pointer is true if the elements, or the map values, are pointers.
+	Pointer bool
+	// Ordered is true if the map keys can be sorted.
+	Ordered bool
+}
+
+// Each applies a rule to the elements of a list.
+type Each struct {
+	Rule ValidationRule
+}
+
+// Keys applies a rule to the keys of a map.
+type Keys struct {
+	Rule ValidationRule
+}
+
+// Values applies a rule to the values of a map.
+type Values struct {
+	Rule ValidationRule
+}
+
+func (r *Each) Inner() ValidationRule   { return r.Rule }
+func (r *Keys) Inner() ValidationRule   { return r.Rule }
+func (r *Values) Inner() ValidationRule { return r.Rule }
+
+func (r *Each) String() string   { return fmt.Sprintf("Each(%s)", r.Rule) }
+func (r *Keys) String() string   { return fmt.Sprintf("Keys(%s)", r.Rule) }
+func (r *Values) String() string { return fmt.Sprintf("Values(%s)", r.Rule) }
+
+func (r *Each) ExpandCode(fieldName, fieldNameCode string) string {
+	return ExpandNested(r, fieldName, fieldNameCode, nil)
+}
+
+func (r *Keys) ExpandCode(fieldName, fieldNameCode string) string {
+	return ExpandNested(r, fieldName, fieldNameCode, nil)
+}
+
+func (r *Values) ExpandCode(fieldName, fieldNameCode string) string {
+	return ExpandNested(r, fieldName, fieldNameCode, nil)
+}
+
+func (r *Each) loop(path, value string, container Container, depth int) (string, string, string) {
+	index, item := loopVar("i", depth), loopVar("item", depth)
+	header := fmt.Sprintf("for %s, %s := range %s {\n", index, item, value)
+	return header, fmt.Sprintf("validation.Index(%s, %s)", path, index), item
+}
+
+func (r *Keys) loop(path, value string, container Container, depth int) (string, string, string) {
+	key := loopVar("k", depth)
+	header := fmt.Sprintf("for _, %s := range %s(%s) {\n", key, sortedKeys(container), value)
+	return header, fmt.Sprintf("validation.Key(%s, %s)", path, key), key
+}
+
+func (r *Values) loop(path, value string, container Container, depth int) (string, string, string) {
+	key, elem := loopVar("k", depth), loopVar("value", depth)
+	indexed := value
+	if strings.HasPrefix(value, "*") {
+		// the map is dereferenced before it is indexed
+		indexed = fmt.Sprintf("(%s)", value)
+	}
+	header := fmt.Sprintf("for _, %s := range %s(%s) {\n%s := %s[%s]\n", key, sortedKeys(container), value, elem, indexed, key)
+	return header, fmt.Sprintf("validation.Key(%s, %s)", path, key), elem
+}
+
+// ExpandNested generates the code of the rule for the field with the JSON
+// path the Go expression fieldName evaluates to. The wrappers of the rule
+// range over the containers, from the outermost to the innermost; missing
+// containers hold values and have keys that cannot be sorted.
+func ExpandNested(rule ValidationRule, fieldName, fieldNameCode string, containers []Container) string {
+	return expandNested(rule, fieldName, fieldNameCode, containers, 0)
+}
+
+func expandNested(rule ValidationRule, path, value string, containers []Container, depth int) string {
+	var container Container
+	if depth < len(containers) {
+		container = containers[depth]
+	}
+	if rule, ok := rule.(ContainerRule); ok {
+		return rule.ExpandContainer(path, value, container)
+	}
+	wrapper, ok := rule.(Wrapper)
+	if !ok {
+		return rule.ExpandCode(path, value)
+	}
+	var sb strings.Builder
+	header, elemPath, elemValue := wrapper.loop(path, value, container, depth)
+	sb.WriteString(header)
+	_, keys := wrapper.(*Keys)
+	if container.Pointer && !keys {
+		// nil elements are not validated
+		sb.WriteString(fmt.Sprintf("if %s != nil {\n", elemValue))
+		elemValue = "*" + elemValue
+	}
+	sb.WriteString(expandNested(wrapper.Inner(), elemPath, elemValue, containers, depth+1))
+	if container.Pointer && !keys {
+		sb.WriteString("}\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// parseWrapped parses the rule wrapped by a wrapper, e.g. `length(min = 1)`.
+func parseWrapped(registry map[string]ValidatorRuleParser, attr string) (ValidationRule, error) {
+	attr = strings.TrimSpace(attr)
+	name, attrs, ok := strings.Cut(attr, "(")
+	if !ok || !strings.HasSuffix(attrs, ")") {
+		return nil, fmt.Errorf("invalid wrapped rule, expected `name(...)` got: %s", attr)
+	}
+	parser, ok := registry[strings.TrimSpace(name)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported validator: %s", name)
+	}
+	return parser(strings.TrimSuffix(attrs, ")"))
+}
+
+// loopVar returns the name of a loop variable, unique within nested loops.
+func loopVar(name string, depth int) string {
+	if depth == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s%d", name, depth)
+}
+
+// sortedKeys returns the function returning the keys of the map in order.
+func sortedKeys(container Container) string {
+	if container.Ordered {
+		return "validation.SortedKeys"
+	}
+	return "validation.SortedKeysFunc"
+}
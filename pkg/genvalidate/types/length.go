@@ -2,7 +2,10 @@ package types
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+
+	"github.com/iancoleman/strcase"
 )
 
 type Length struct {
@@ -53,48 +56,55 @@ func (r *Length) ExpandCode(fieldName, fieldNameCode string) string {
 	// Generate validation conditions
 	if r.Min != nil {
 		sb.WriteString(fmt.Sprintf("if %s < %d {\n", lengthCheck, *r.Min))
-		sb.WriteString(generateError(fmt.Sprintf("len %s must be > %%d", fieldName), float64(*r.Min), r.Message, r.Code))
+		sb.WriteString(generateError(fieldName, "length", "min", "length must be at least {min}, got {len}", r.Message, r.Code,
+			arg("min", strconv.Itoa(*r.Min)), arg("len", lengthCheck)))
 		sb.WriteString("}\n")
 	}
 
 	if r.Max != nil {
 		sb.WriteString(fmt.Sprintf("if %s > %d {\n", lengthCheck, *r.Max))
-		sb.WriteString(generateError(fmt.Sprintf("len %s must be < %%d", fieldName), float64(*r.Max), r.Message, r.Code))
+		sb.WriteString(generateError(fieldName, "length", "max", "length must be at most {max}, got {len}", r.Message, r.Code,
+			arg("max", strconv.Itoa(*r.Max)), arg("len", lengthCheck)))
 		sb.WriteString("}\n")
 	}
 
 	if r.Equal != nil {
 		sb.WriteString(fmt.Sprintf("if %s != %d {\n", lengthCheck, *r.Equal))
-		sb.WriteString(generateError(fmt.Sprintf("len %s must be = %%d", fieldName), float64(*r.Equal), r.Message, r.Code))
+		sb.WriteString(generateError(fieldName, "length", "equal", "length must be {equal}, got {len}", r.Message, r.Code,
+			arg("equal", strconv.Itoa(*r.Equal)), arg("len", lengthCheck)))
 		sb.WriteString("}\n")
 	}
 
 	return sb.String()
 }
 
-// Helper function to generate error handling code
-func generateError(message string, value float64, customMsg, code *string) string {
-	var sb strings.Builder
-	sb.WriteString("\terrs = errors.Join(errs, fmt.Errorf(\"")
-
-	// If a custom message is provided, use it
+// generateError generates the code reporting the failure of the check of a
+// rule of the given kind for the field with the JSON path the Go expression
+// fieldName evaluates to, with the default message template, or the custom
+// template of the rule. The code of the failure defaults to `<kind>.<check>`.
+// args are the placeholder values of the template, see arg.
+func generateError(fieldName, kind, check, template string, customMsg, code *string, args ...string) string {
 	if customMsg != nil {
-		sb.WriteString(*customMsg)
-	} else {
-		sb.WriteString(message)
+		template = *customMsg
 	}
-
-	// Determine correct formatting: `%d` for integers, `%f` for floats
-	if value == float64(int(value)) {
-		sb.WriteString(`", ` + fmt.Sprintf("%d", int(value)) + ")") // Use integer format
-	} else {
-		sb.WriteString(`", ` + fmt.Sprintf("%f", value) + ")") // Use float format
+	errCode := kind
+	if check != "" {
+		errCode += "." + check
 	}
-
 	if code != nil {
-		sb.WriteString(fmt.Sprintf(" // Error code: %s", *code))
+		errCode = *code
+	}
+	values := "nil"
+	if len(args) > 0 {
+		values = fmt.Sprintf("validation.Args{%s}", strings.Join(args, ", "))
 	}
+	// the template is quoted so it can hold any character
+	return fmt.Sprintf("\terrs = errors.Join(errs, validation.Invalid(%s, validation.Err%s, %q, %s, %s))\n",
+		fieldName, strcase.ToCamel(kind), errCode, strconv.Quote(template), values)
+}
 
-	sb.WriteString(")\n")
-	return sb.String()
+// arg returns the placeholder value of a message template with the given
+// name and Go expression.
+func arg(name, expr string) string {
+	return fmt.Sprintf("%q: %s", name, expr)
 }
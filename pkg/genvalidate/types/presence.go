@@ -0,0 +1,193 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The checks of a Presence rule.
+const (
+	Required        = "required"
+	RequiredIf      = "required_if"
+	RequiredWith    = "required_with"
+	RequiredWithout = "required_without"
+	ExcludedWith    = "excluded_with"
+)
+
+// Presence requires a field to be set, or not to be set, depending on the
+// other fields of the struct, e.g. `required_if(field=Type, value=ospf)` or
+// `excluded_with(fields=[ISIS])`. Pointers, slices, maps and strings are
+// set if they are not nil or empty, other values if they are not zero. The
+// message of required_if refers to the field of its condition with
+// `{if_field}`, since `{field}` is the validated field.
+type Presence struct {
+	// Field and Value are the condition of required_if.
+	Field *string `json:"field,omitempty"`
+	Value *string `json:"value,omitempty"`
+	// Fields are the fields of required_with, required_without and
+	// excluded_with.
+	Fields  *[]string `json:"fields,omitempty"`
+	Message *string   `json:"message,omitempty"`
+	Code    *string   `json:"code,omitempty"`
+
+	check string
+	// names are the JSON names of the referenced fields used in messages.
+	names map[string]string
+}
+
+func parsePresence(check string) ValidatorRuleParser {
+	return func(attr string) (ValidationRule, error) {
+		r, err := parseKeyValuePairs[Presence](attr)
+		if err != nil {
+			return nil, err
+		}
+		r.check = check
+		switch check {
+		case RequiredIf:
+			if r.Field == nil || r.Value == nil {
+				return nil, fmt.Errorf("%s requires a field and a value", check)
+			}
+		case RequiredWith, RequiredWithout, ExcludedWith:
+			if r.Fields == nil || len(*r.Fields) == 0 {
+				return nil, fmt.Errorf("%s requires fields", check)
+			}
+		}
+		return r, nil
+	}
+}
+
+func (r *Presence) String() string {
+	var attrs []string
+	if r.Field != nil {
+		attrs = append(attrs, fmt.Sprintf("field=%s", *r.Field))
+	}
+	if r.Value != nil {
+		attrs = append(attrs, fmt.Sprintf("value=%q", *r.Value))
+	}
+	if r.Fields != nil {
+		attrs = append(attrs, fmt.Sprintf("fields=[%s]", strings.Join(*r.Fields, ",")))
+	}
+	if r.Message != nil {
+		attrs = append(attrs, fmt.Sprintf("message=%q", *r.Message))
+	}
+	if r.Code != nil {
+		attrs = append(attrs, fmt.Sprintf("code=%q", *r.Code))
+	}
+	return fmt.Sprintf("Presence[%s](%s)", r.check, strings.Join(attrs, ", "))
+}
+
+// Refs returns the Go names of the fields the condition of the rule refers
+// to.
+func (r *Presence) Refs() []string {
+	if r.Field != nil {
+		return []string{*r.Field}
+	}
+	if r.Fields != nil {
+		return *r.Fields
+	}
+	return nil
+}
+
+// Resolve replaces the fields the rule refers to, given by JSON or Go name,
+// with their Go name. resolve returns the Go and the JSON name of a field of
+// the struct.
+func (r *Presence) Resolve(resolve func(name string) (goName, jsonName string, ok bool)) error {
+	r.names = map[string]string{}
+	lookup := func(name string) (string, error) {
+		goName, jsonName, ok := resolve(name)
+		if !ok {
+			return "", fmt.Errorf("%s refers to unknown field %s", r.check, name)
+		}
+		r.names[goName] = jsonName
+		return goName, nil
+	}
+	if r.Field != nil {
+		goName, err := lookup(*r.Field)
+		if err != nil {
+			return err
+		}
+		r.Field = &goName
+	}
+	if r.Fields != nil {
+		fields := make([]string, 0, len(*r.Fields))
+		for _, name := range *r.Fields {
+			goName, err := lookup(name)
+			if err != nil {
+				return err
+			}
+			fields = append(fields, goName)
+		}
+		r.Fields = &fields
+	}
+	return nil
+}
+
+// ExpandCode generates the check of the field. fieldNameCode is the field of
+// the receiver, e.g. r.Area, and the referenced fields are fields of the
+// same receiver.
+func (r *Presence) ExpandCode(fieldName, fieldNameCode string) string {
+	receiver := fieldNameCode[:strings.LastIndex(fieldNameCode, ".")+1]
+	isSet := func(code string) string {
+		return fmt.Sprintf("validation.IsSet(%s)", code)
+	}
+	// anyOf returns the condition that is true if any of the fields is set,
+	// or not set if negate is set.
+	anyOf := func(negate bool) string {
+		var conditions []string
+		for _, name := range *r.Fields {
+			condition := isSet(receiver + name)
+			if negate {
+				condition = "!" + condition
+			}
+			conditions = append(conditions, condition)
+		}
+		if len(conditions) == 1 {
+			return conditions[0]
+		}
+		return "(" + strings.Join(conditions, " || ") + ")"
+	}
+
+	var sb strings.Builder
+	switch r.check {
+	case Required:
+		sb.WriteString(fmt.Sprintf("if !%s {\n", isSet(fieldNameCode)))
+		sb.WriteString(generateError(fieldName, "required", "", "field is required", r.Message, r.Code))
+	case RequiredIf:
+		sb.WriteString(fmt.Sprintf("if validation.Equals(%s%s, %s) && !%s {\n", receiver, *r.Field, strconv.Quote(*r.Value), isSet(fieldNameCode)))
+		sb.WriteString(generateError(fieldName, "required", "if", "field is required when {if_field} is {value}", r.Message, r.Code,
+			arg("if_field", strconv.Quote(r.jsonName(*r.Field))), arg("value", strconv.Quote(*r.Value))))
+	case RequiredWith:
+		sb.WriteString(fmt.Sprintf("if %s && !%s {\n", anyOf(false), isSet(fieldNameCode)))
+		sb.WriteString(generateError(fieldName, "required", "with", "field is required when {fields} is set", r.Message, r.Code,
+			arg("fields", r.fieldNames())))
+	case RequiredWithout:
+		sb.WriteString(fmt.Sprintf("if %s && !%s {\n", anyOf(true), isSet(fieldNameCode)))
+		sb.WriteString(generateError(fieldName, "required", "without", "field is required when {fields} is not set", r.Message, r.Code,
+			arg("fields", r.fieldNames())))
+	case ExcludedWith:
+		sb.WriteString(fmt.Sprintf("if %s && %s {\n", anyOf(false), isSet(fieldNameCode)))
+		sb.WriteString(generateError(fieldName, "excluded", "with", "field must not be set when {fields} is set", r.Message, r.Code,
+			arg("fields", r.fieldNames())))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// fieldNames returns the Go expression of the JSON names of the fields.
+func (r *Presence) fieldNames() string {
+	names := make([]string, 0, len(*r.Fields))
+	for _, name := range *r.Fields {
+		names = append(names, r.jsonName(name))
+	}
+	return strconv.Quote(strings.Join(names, ", "))
+}
+
+// jsonName returns the JSON name of the referenced field, or its Go name if
+// it has none.
+func (r *Presence) jsonName(name string) string {
+	if jsonName := r.names[name]; jsonName != "" {
+		return jsonName
+	}
+	return name
+}
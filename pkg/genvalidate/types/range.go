@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -52,31 +53,41 @@ func (r *Range) String() string {
 
 func (r *Range) ExpandCode(fieldName, fieldNameCode string) string {
 	var sb strings.Builder
+	value := arg("value", fieldNameCode)
 
 	// Generate validation conditions
 	if r.Min != nil {
 		sb.WriteString(fmt.Sprintf("if %s < %f {\n", fieldNameCode, *r.Min))
-		sb.WriteString(generateError(fmt.Sprintf("%s must be >= %%f", fieldName), *r.Min, r.Message, r.Code))
+		sb.WriteString(generateError(fieldName, "range", "min", "must be greater than or equal to {min}, got {value}", r.Message, r.Code,
+			arg("min", formatFloat(*r.Min)), value))
 		sb.WriteString("}\n")
 	}
 
 	if r.Max != nil {
 		sb.WriteString(fmt.Sprintf("if %s > %f {\n", fieldNameCode, *r.Max))
-		sb.WriteString(generateError(fmt.Sprintf("%s must be <= %%f", fieldName), *r.Max, r.Message, r.Code))
+		sb.WriteString(generateError(fieldName, "range", "max", "must be less than or equal to {max}, got {value}", r.Message, r.Code,
+			arg("max", formatFloat(*r.Max)), value))
 		sb.WriteString("}\n")
 	}
 
 	if r.ExclusiveMin != nil {
 		sb.WriteString(fmt.Sprintf("if %s <= %f {\n", fieldNameCode, *r.ExclusiveMin))
-		sb.WriteString(generateError(fmt.Sprintf("%s must be > %%f", fieldName), *r.ExclusiveMin, r.Message, r.Code))
+		sb.WriteString(generateError(fieldName, "range", "exclusive_min", "must be greater than {exclusive_min}, got {value}", r.Message, r.Code,
+			arg("exclusive_min", formatFloat(*r.ExclusiveMin)), value))
 		sb.WriteString("}\n")
 	}
 
 	if r.ExclusiveMax != nil {
 		sb.WriteString(fmt.Sprintf("if %s >= %f {\n", fieldNameCode, *r.ExclusiveMax))
-		sb.WriteString(generateError(fmt.Sprintf("%s must be < %%f", fieldName), *r.ExclusiveMax, r.Message, r.Code))
+		sb.WriteString(generateError(fieldName, "range", "exclusive_max", "must be less than {exclusive_max}, got {value}", r.Message, r.Code,
+			arg("exclusive_max", formatFloat(*r.ExclusiveMax)), value))
 		sb.WriteString("}\n")
 	}
 
 	return sb.String()
-}
\ No newline at end of file
+}
+
+// formatFloat returns the shortest Go literal of the limit of a range.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
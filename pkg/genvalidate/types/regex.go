@@ -0,0 +1,53 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type Regex struct {
+	Pattern *string `json:"pattern,omitempty"`
+	Message *string `json:"message,omitempty"`
+	Code    *string `json:"code,omitempty"`
+}
+
+func parseRegex(attr string) (ValidationRule, error) {
+	rule, err := parseKeyValuePairs[Regex](attr)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Pattern == nil {
+		return nil, fmt.Errorf("regex requires a pattern, got: %s", attr)
+	}
+	if _, err := regexp.Compile(*rule.Pattern); err != nil {
+		return nil, fmt.Errorf("invalid regex pattern %q: %w", *rule.Pattern, err)
+	}
+	return rule, nil
+}
+
+func (r *Regex) String() string {
+	var sb strings.Builder
+	sb.WriteString("Regex(")
+	sb.WriteString(fmt.Sprintf("pattern=%q", *r.Pattern))
+	if r.Message != nil {
+		sb.WriteString(fmt.Sprintf(", message=%q", *r.Message))
+	}
+	if r.Code != nil {
+		sb.WriteString(fmt.Sprintf(", code=%q", *r.Code))
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+func (r *Regex) ExpandCode(fieldName, fieldNameCode string) string {
+	var sb strings.Builder
+	value := fmt.Sprintf("string(%s)", fieldNameCode)
+
+	sb.WriteString(fmt.Sprintf("if !validation.MatchString(%q, %s) {\n", *r.Pattern, value))
+	sb.WriteString(generateError(fieldName, "regex", "match", "must match {pattern}, got {value}", r.Message, r.Code,
+		arg("pattern", fmt.Sprintf("%q", *r.Pattern)), arg("value", value)))
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
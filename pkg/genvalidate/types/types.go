@@ -11,20 +11,54 @@ import (
 
 type ValidatorRuleParser func(string) (ValidationRule, error)
 
+// A ValidationRule generates the checks of a rule. fieldName is the Go
+// expression of the JSON path of the field, e.g. `"name"`, and fieldNameCode
+// the Go expression of the value of the field.
 type ValidationRule interface {
 	String() string
 	ExpandCode(fieldName, fieldNameCode string) string
 }
 
 func InitValidationRuleRegistry() map[string]ValidatorRuleParser {
-	return map[string]ValidatorRuleParser{
+	registry := map[string]ValidatorRuleParser{
 		"length": func(attr string) (ValidationRule, error) {
 			return parseKeyValuePairs[Length](attr)
 		},
 		"range": func(attr string) (ValidationRule, error) {
 			return parseKeyValuePairs[Range](attr)
 		},
+		"regex": parseRegex,
+		"unique": func(attr string) (ValidationRule, error) {
+			return parseKeyValuePairs[Unique](attr)
+		},
+		Required:        parsePresence(Required),
+		RequiredIf:      parsePresence(RequiredIf),
+		RequiredWith:    parsePresence(RequiredWith),
+		RequiredWithout: parsePresence(RequiredWithout),
+		ExcludedWith:    parsePresence(ExcludedWith),
+	}
+	registry["each"] = func(attr string) (ValidationRule, error) {
+		rule, err := parseWrapped(registry, attr)
+		if err != nil {
+			return nil, err
+		}
+		return &Each{Rule: rule}, nil
+	}
+	registry["keys"] = func(attr string) (ValidationRule, error) {
+		rule, err := parseWrapped(registry, attr)
+		if err != nil {
+			return nil, err
+		}
+		return &Keys{Rule: rule}, nil
 	}
+	registry["values"] = func(attr string) (ValidationRule, error) {
+		rule, err := parseWrapped(registry, attr)
+		if err != nil {
+			return nil, err
+		}
+		return &Values{Rule: rule}, nil
+	}
+	return registry
 	/*
 		"card": func(attr string) ValidationRule {
 			return parseKeyValuePairs[Card](attr)
@@ -44,9 +78,6 @@ func InitValidationRuleRegistry() map[string]ValidatorRuleParser {
 		"must_match": func(attr string) ValidationRule {
 			return parseKeyValuePairs[MustMatch](attr)
 		},
-		"regex": func(attr string) ValidationRule {
-			return parseKeyValuePairs[Regex](attr)
-		},
 		"custom": func(attr string) ValidationRule {
 			return parseKeyValuePairs[Custom](attr)
 		},
@@ -57,8 +88,7 @@ func parseKeyValuePairs[T any](input string) (*T, error) {
 	var result T
 	resultValue := reflect.ValueOf(&result).Elem()
 
-
-	pairs := strings.Split(input, ",")
+	pairs := splitPairs(input)
 	for _, pair := range pairs {
 		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
 		if len(kv) != 2 {
@@ -90,6 +120,18 @@ func parseKeyValuePairs[T any](input string) (*T, error) {
 			}
 		case reflect.String:
 			newValue := strings.Trim(value, `"`)
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				newValue = unquoted
+			}
+			field.Set(reflect.ValueOf(&newValue))
+		case reflect.Slice:
+			// lists are written as [a,b]
+			var newValue []string
+			for _, item := range strings.Split(strings.Trim(value, "[]"), ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					newValue = append(newValue, item)
+				}
+			}
 			field.Set(reflect.ValueOf(&newValue))
 		default:
 			fmt.Printf("Unsupported field type: %s\n", field.Type().Elem().Kind())
@@ -98,3 +140,35 @@ func parseKeyValuePairs[T any](input string) (*T, error) {
 
 	return &result, nil
 }
+
+// splitPairs splits the attributes of a rule at the commas that are not
+// quoted or in brackets, so messages and lists can contain commas.
+func splitPairs(input string) []string {
+	var pairs []string
+	start := 0
+	var quote rune
+	escaped := false
+	depth := 0
+	for i, c := range input {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0 && c == '\\':
+			escaped = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '`':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == ',' && depth == 0:
+			pairs = append(pairs, input[start:i])
+			start = i + 1
+		}
+	}
+	return append(pairs, input[start:])
+}
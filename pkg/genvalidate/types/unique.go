@@ -0,0 +1,110 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Unique requires the elements of a list to be unique, or the values of the
+// key fields of the elements if keys are set.
+type Unique struct {
+	Keys    *[]string `json:"keys,omitempty"`
+	Message *string   `json:"message,omitempty"`
+	Code    *string   `json:"code,omitempty"`
+
+	// pointers are the keys whose fields are pointers, compared by the value
+	// they point to.
+	pointers map[string]bool
+}
+
+// Resolve replaces the keys of the rule with the Go names of the key fields.
+// resolve returns the Go name of a key field and whether it is a pointer.
+func (r *Unique) Resolve(resolve func(key string) (goName string, pointer bool, err error)) error {
+	if r.Keys == nil {
+		return nil
+	}
+	r.pointers = map[string]bool{}
+	keys := make([]string, 0, len(*r.Keys))
+	for _, key := range *r.Keys {
+		goName, pointer, err := resolve(key)
+		if err != nil {
+			return err
+		}
+		r.pointers[goName] = pointer
+		keys = append(keys, goName)
+	}
+	r.Keys = &keys
+	return nil
+}
+
+func (r *Unique) String() string {
+	var sb strings.Builder
+	sb.WriteString("Unique(")
+	var attrs []string
+	if r.Keys != nil {
+		attrs = append(attrs, fmt.Sprintf("keys=[%s]", strings.Join(*r.Keys, ",")))
+	}
+	if r.Message != nil {
+		attrs = append(attrs, fmt.Sprintf("message=%q", *r.Message))
+	}
+	if r.Code != nil {
+		attrs = append(attrs, fmt.Sprintf("code=%q", *r.Code))
+	}
+	sb.WriteString(strings.Join(attrs, ", "))
+	sb.WriteString(")")
+	return sb.String()
+}
+
+func (r *Unique) ExpandCode(fieldName, fieldNameCode string) string {
+	return r.ExpandContainer(fieldName, fieldNameCode, Container{})
+}
+
+// ExpandContainer generates the check of the list, remembering the index of
+// the first element with each key. Nil elements are ignored and pointer keys
+// are compared by the value they point to.
+func (r *Unique) ExpandContainer(fieldName, fieldNameCode string, container Container) string {
+	var sb strings.Builder
+	list := fieldNameCode
+	if strings.HasPrefix(list, "*") {
+		list = fmt.Sprintf("(%s)", list)
+	}
+	elem := fmt.Sprintf("%s[j]", list)
+
+	key := elem
+	if container.Pointer {
+		key = "*" + elem
+	}
+	keyField := func(k string) string {
+		if r.pointers[k] {
+			return fmt.Sprintf("validation.UniqueKey(%s.%s)", elem, k)
+		}
+		return fmt.Sprintf("%s.%s", elem, k)
+	}
+	switch {
+	case r.Keys != nil && len(*r.Keys) == 1:
+		key = keyField((*r.Keys)[0])
+	case r.Keys != nil:
+		fields := make([]string, 0, len(*r.Keys))
+		for _, k := range *r.Keys {
+			fields = append(fields, keyField(k))
+		}
+		key = fmt.Sprintf("[%d]any{%s}", len(fields), strings.Join(fields, ", "))
+	}
+
+	sb.WriteString("{\n")
+	sb.WriteString(fmt.Sprintf("seen := make(map[any]int, len(%s))\n", list))
+	sb.WriteString(fmt.Sprintf("for j := range %s {\n", list))
+	if container.Pointer {
+		sb.WriteString(fmt.Sprintf("if %s == nil {\ncontinue\n}\n", elem))
+	}
+	sb.WriteString(fmt.Sprintf("key := %s\n", key))
+	sb.WriteString("if first, ok := seen[key]; ok {\n")
+	sb.WriteString(generateError(fmt.Sprintf("validation.Index(%s, j)", fieldName), "unique", "duplicate", "duplicate of element {index}", r.Message, r.Code,
+		arg("index", "first")))
+	sb.WriteString("continue\n}\n")
+	sb.WriteString("seen[key] = j\n")
+	sb.WriteString("}\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
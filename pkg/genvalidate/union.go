@@ -0,0 +1,370 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"os"
+	"strings"
+)
+
+const unionMarker = "// +union("
+
+// A Union is a set of optional fields of a struct of which at most one, or
+// exactly one, is set, e.g.
+// `// +union(members=[ospf,isis], mode=at_most_one)`. The discriminator
+// field, if any, holds the JSON name of the member that is set.
+type Union struct {
+	// Members are the Go names of the member fields.
+	Members []string
+	// JSONNames are the JSON names of the member fields.
+	JSONNames []string
+	// ExactlyOne requires a member to be set, otherwise no member may be set.
+	ExactlyOne bool
+	// Discriminator is the Go name of the discriminator field, if any.
+	Discriminator string
+	// DiscriminatorJSONName is the JSON name of the discriminator field.
+	DiscriminatorJSONName string
+	// DiscriminatorPointer is true if the discriminator field is a pointer.
+	DiscriminatorPointer bool
+}
+
+// unions returns the unions documenting the struct type decl.
+func (r *Loader) unions(decl *TypeDecl) ([]Union, error) {
+	st, ok := decl.Spec.Type.(*ast.StructType)
+	doc := decl.Doc()
+	if !ok || doc == nil {
+		return nil, nil
+	}
+	var unions []Union
+	for _, comment := range doc.List {
+		text := strings.TrimSpace(comment.Text)
+		if !strings.HasPrefix(text, unionMarker) || !strings.HasSuffix(text, ")") {
+			continue
+		}
+		union, err := r.parseUnion(decl.File, st, text[len(unionMarker):len(text)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid union of type %s: %w", decl.Name, err)
+		}
+		unions = append(unions, union)
+	}
+	return unions, nil
+}
+
+// parseUnion parses the attributes of a union marker, e.g.
+// `discriminator=type, members=[ospf,isis], mode=exactly_one`. Fields are
+// given by JSON or Go name. The mode defaults to at_most_one.
+func (r *Loader) parseUnion(file *File, st *ast.StructType, attrs string) (Union, error) {
+	var union Union
+	for _, attr := range splitTopLevel(attrs) {
+		key, value, ok := strings.Cut(attr, "=")
+		if !ok {
+			return union, fmt.Errorf("invalid attribute %q", attr)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "members":
+			for _, name := range strings.Split(strings.Trim(value, "[]"), ",") {
+				if name = strings.TrimSpace(name); name == "" {
+					continue
+				}
+				field, goName, jsonName, ok := structField(st, name)
+				if !ok {
+					return union, fmt.Errorf("unknown member %s", name)
+				}
+				if len(field.Names) == 0 {
+					return union, fmt.Errorf("member %s is an embedded field", name)
+				}
+				union.Members = append(union.Members, goName)
+				union.JSONNames = append(union.JSONNames, jsonName)
+			}
+		case "mode":
+			switch value {
+			case "exactly_one":
+				union.ExactlyOne = true
+			case "at_most_one":
+			default:
+				return union, fmt.Errorf("unsupported mode %s", value)
+			}
+		case "discriminator":
+			field, goName, jsonName, ok := structField(st, value)
+			if !ok {
+				return union, fmt.Errorf("unknown discriminator %s", value)
+			}
+			if !r.isString(file, derefType(field.Type)) {
+				return union, fmt.Errorf("discriminator %s is not a string", value)
+			}
+			union.Discriminator = goName
+			union.DiscriminatorJSONName = jsonName
+			union.DiscriminatorPointer = isPointerType(field.Type)
+		default:
+			return union, fmt.Errorf("unsupported attribute %s", key)
+		}
+	}
+	if len(union.Members) < 2 {
+		return union, fmt.Errorf("a union requires at least two members")
+	}
+	return union, nil
+}
+
+// structField returns the field of the struct type st with the given Go or
+// JSON name.
+func structField(st *ast.StructType, name string) (*ast.Field, string, string, bool) {
+	for _, field := range st.Fields.List {
+		goName := fieldNames(field)[0]
+		json, _ := jsonName(field)
+		if goName != "" && (name == goName || name == json) {
+			return field, goName, json, true
+		}
+	}
+	return nil, "", "", false
+}
+
+// generateUnion generates the check of the union of the receiver r.
+func generateUnion(union Union) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("// %s of %s is set\n", unionMode(union), strings.Join(union.JSONNames, ", ")))
+	sb.WriteString(fmt.Sprintf("errs = errors.Join(errs, %s.Check(%s, %s))\n", unionLiteral(union), unionSet(union), discriminatorValue(union)))
+	return sb.String()
+}
+
+// unionLiteral returns the Go expression of the validation.Union describing
+// the union.
+func unionLiteral(union Union) string {
+	var sb strings.Builder
+	sb.WriteString("validation.Union{\n")
+	sb.WriteString(fmt.Sprintf("Members: []string{%s},\n", quoteAll(union.JSONNames)))
+	if union.ExactlyOne {
+		sb.WriteString("ExactlyOne: true,\n")
+	}
+	if union.Discriminator != "" {
+		sb.WriteString(fmt.Sprintf("Discriminator: %q,\n", union.DiscriminatorJSONName))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// unionSet returns the Go expression of the list reporting which members of
+// the union are set.
+func unionSet(union Union) string {
+	var set []string
+	for _, member := range union.Members {
+		set = append(set, fmt.Sprintf("validation.IsSet(r.%s)", member))
+	}
+	return fmt.Sprintf("[]bool{%s}", strings.Join(set, ", "))
+}
+
+// unionMode describes the mode of the union in the generated comments.
+func unionMode(union Union) string {
+	if union.ExactlyOne {
+		return "exactly one"
+	}
+	return "at most one"
+}
+
+// discriminatorValue returns the Go expression of the value of the
+// discriminator of the union, or an empty string.
+func discriminatorValue(union Union) string {
+	switch {
+	case union.Discriminator == "":
+		return `""`
+	case union.DiscriminatorPointer:
+		return fmt.Sprintf("string(validation.Deref(r.%s))", union.Discriminator)
+	}
+	return fmt.Sprintf("string(r.%s)", union.Discriminator)
+}
+
+// unionFields returns the Go names of the members and the discriminator of
+// the union.
+func unionFields(union Union) []string {
+	fields := append([]string{}, union.Members...)
+	if union.Discriminator != "" {
+		fields = append(fields, union.Discriminator)
+	}
+	return fields
+}
+
+// A UnionGenerator generates the JSON decoding of the struct types with a
+// discriminated union, which keeps only the member named by the
+// discriminator.
+type UnionGenerator struct {
+	loader *Loader
+}
+
+func NewUnionGenerator(loader *Loader) *UnionGenerator {
+	return &UnionGenerator{
+		loader: loader,
+	}
+}
+
+func (r *UnionGenerator) Generate() error {
+	for _, file := range r.loader.Files() {
+		var body strings.Builder
+		imports := map[string]string{"encoding/json": ""}
+		for _, decl := range r.loader.FileTypes(file) {
+			discriminated, err := r.discriminated(decl)
+			if err != nil {
+				return err
+			}
+			if field := r.embeddedUnion(decl, map[*TypeDecl]bool{}); field != "" {
+				return fmt.Errorf("invalid type %s: the embedded field %s has a discriminated union, whose generated UnmarshalJSON would decode the whole object", decl.Name, field)
+			}
+			if len(discriminated) == 0 {
+				continue
+			}
+			if field := r.promotedUnmarshalJSON(decl); field != "" {
+				return fmt.Errorf("invalid union of type %s: the embedded field %s has an UnmarshalJSON method that replaces the generated decoding", decl.Name, field)
+			}
+			body.WriteString(generateUnmarshalJSON(decl, discriminated))
+			for _, union := range discriminated {
+				if union.DiscriminatorPointer {
+					imports[validationImportPath] = ""
+				}
+			}
+		}
+		if body.Len() > 0 {
+			r.generateUnionCode(file, body.String(), imports)
+		}
+	}
+	return nil
+}
+
+// discriminated returns the unions of the type decl with a discriminator.
+func (r *UnionGenerator) discriminated(decl *TypeDecl) ([]Union, error) {
+	unions, err := r.loader.unions(decl)
+	if err != nil {
+		return nil, err
+	}
+	var discriminated []Union
+	for _, union := range unions {
+		if union.Discriminator != "" {
+			discriminated = append(discriminated, union)
+		}
+	}
+	return discriminated, nil
+}
+
+// embeddedUnion returns the Go name of the embedded field of the struct type
+// decl whose type, or a type it embeds, has a discriminated union, or an
+// empty string. The generated UnmarshalJSON of the union would be promoted
+// to decl.
+func (r *UnionGenerator) embeddedUnion(decl *TypeDecl, visited map[*TypeDecl]bool) string {
+	st, ok := decl.Spec.Type.(*ast.StructType)
+	if !ok || visited[decl] {
+		return ""
+	}
+	visited[decl] = true
+	for _, field := range st.Fields.List {
+		if len(field.Names) > 0 {
+			continue
+		}
+		embedded := r.loader.Resolve(decl.File, derefType(field.Type))
+		if embedded == nil {
+			continue
+		}
+		if discriminated, _ := r.discriminated(embedded); len(discriminated) > 0 || r.embeddedUnion(embedded, visited) != "" {
+			return embeddedFieldName(field.Type)
+		}
+	}
+	return ""
+}
+
+// promotedUnmarshalJSON returns the Go name of the embedded field of the
+// struct type decl that promotes an UnmarshalJSON method, or an empty
+// string. The promoted method would decode the whole object in place of the
+// generated one.
+func (r *UnionGenerator) promotedUnmarshalJSON(decl *TypeDecl) string {
+	for _, field := range decl.Spec.Type.(*ast.StructType).Fields.List {
+		if len(field.Names) > 0 {
+			continue
+		}
+		if r.hasUnmarshalJSON(decl.File, derefType(field.Type), map[*TypeDecl]bool{}) {
+			return embeddedFieldName(field.Type)
+		}
+	}
+	return ""
+}
+
+// hasUnmarshalJSON returns true if the method set of the named type expr,
+// or of a pointer to it, has an UnmarshalJSON method.
+func (r *UnionGenerator) hasUnmarshalJSON(file *File, expr ast.Expr, visited map[*TypeDecl]bool) bool {
+	decl := r.loader.Resolve(file, expr)
+	if decl == nil {
+		sel, ok := expr.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return false
+		}
+		importPath, ok := file.Imports[pkg.Name]
+		return ok && r.loader.foreignMethod(importPath, sel.Sel.Name, "UnmarshalJSON") != nil
+	}
+	if visited[decl] {
+		return false
+	}
+	visited[decl] = true
+	if _, ok := r.loader.methods[decl.File.ImportPath+"."+decl.Name]["UnmarshalJSON"]; ok {
+		return true
+	}
+	if discriminated, _ := r.discriminated(decl); len(discriminated) > 0 {
+		return true
+	}
+	st, ok := decl.Spec.Type.(*ast.StructType)
+	if !ok {
+		return false
+	}
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 && r.hasUnmarshalJSON(decl.File, derefType(field.Type), visited) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *UnionGenerator) generateUnionCode(file *File, body string, imports map[string]string) {
+	outputFile := strings.TrimSuffix(file.Path, ".go") + "_union.go"
+
+	var sb strings.Builder
+	sb.WriteString(generatedHeader + "\n")
+	sb.WriteString(fmt.Sprintf("package %s\n\n", file.Package))
+	writeImports(&sb, imports)
+	sb.WriteString(body)
+
+	if err := os.WriteFile(outputFile, []byte(sb.String()), 0644); err != nil {
+		fmt.Println("Error writing union file:", err)
+		return
+	}
+	formatGoFile(outputFile)
+	fmt.Println("Generated union file:", outputFile)
+}
+
+// generateUnmarshalJSON generates the UnmarshalJSON method of the struct
+// type decl. It keeps only the member of each union named by its
+// discriminator and clears the others. Members missing, or set without a
+// discriminator naming a member, are left to the validation.
+func generateUnmarshalJSON(decl *TypeDecl, unions []Union) string {
+	var sb strings.Builder
+	sb.WriteString("// UnmarshalJSON decodes the object and keeps only the members of its unions\n")
+	sb.WriteString("// named by their discriminator.\n")
+	sb.WriteString(fmt.Sprintf("func (r *%s) UnmarshalJSON(data []byte) error {\n", decl.Name))
+	sb.WriteString("// plain has the fields but not the methods of the type\n")
+	sb.WriteString(fmt.Sprintf("type plain %s\n", decl.Name))
+	sb.WriteString("if err := json.Unmarshal(data, (*plain)(r)); err != nil {\nreturn err\n}\n")
+	sb.WriteString("var zero plain\n")
+	for _, union := range unions {
+		sb.WriteString(fmt.Sprintf("switch %s {\n", discriminatorValue(union)))
+		for i, jsonName := range union.JSONNames {
+			sb.WriteString(fmt.Sprintf("case %q:\n", jsonName))
+			for j, member := range union.Members {
+				if j != i {
+					sb.WriteString(fmt.Sprintf("r.%s = zero.%s\n", member, member))
+				}
+			}
+		}
+		sb.WriteString("}\n")
+	}
+	sb.WriteString("return nil\n")
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
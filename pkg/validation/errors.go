@@ -0,0 +1,175 @@
+// Package validation provides the types and helpers used by the generated
+// Validate methods.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// A Validator validates itself. The generated validators call the Validate
+// method of the nested values whose type implements Validator, including
+// the types of other modules.
+type Validator interface {
+	Validate() error
+}
+
+// A Kind classifies the failures of validation rules. The errors reported
+// by the generated validators match their kind and their code with
+// errors.Is, e.g. errors.Is(err, ErrLength) or
+// errors.Is(err, Kind("length.min")).
+type Kind string
+
+func (k Kind) Error() string {
+	return string(k)
+}
+
+// The kinds of the failures reported by the generated validators. The
+// default code of a failure is its kind followed by the failed check, e.g.
+// length.min, length.max, length.equal, range.min, range.max,
+// range.exclusive_min, range.exclusive_max, regex.match, unique.duplicate,
+// required.if, required.with, required.without, excluded.with,
+// union.at_most_one, union.exactly_one and union.discriminator.
+const (
+	ErrLength Kind = "length"
+	ErrRange  Kind = "range"
+	ErrRegex  Kind = "regex"
+	ErrEnum   Kind = "enum"
+	// ErrUnique is reported for the duplicate elements of a list.
+	ErrUnique Kind = "unique"
+	// ErrRequired is reported when a required field is not set.
+	ErrRequired Kind = "required"
+	// ErrExcluded is reported when a field is set together with a field that
+	// excludes it.
+	ErrExcluded Kind = "excluded"
+	// ErrUnion is reported when the members of a union that are set do not
+	// match its mode or its discriminator.
+	ErrUnion Kind = "union"
+	// ErrImmutable is reported when an update changes an immutable field.
+	ErrImmutable Kind = "immutable"
+	// ErrDeprecated is reported as warning when a deprecated field is set.
+	ErrDeprecated Kind = "deprecated"
+	// ErrCycle is reported when a value of a recursive type refers to itself.
+	ErrCycle Kind = "cycle"
+	// ErrDepth is reported when the values of a recursive type are nested
+	// deeper than the limit of the options.
+	ErrDepth Kind = "depth"
+)
+
+// Severity is the severity of a validation error.
+type Severity string
+
+const (
+	// SeverityError rejects the validated object.
+	SeverityError Severity = "error"
+	// SeverityWarning is reported to the user without rejecting the object.
+	SeverityWarning Severity = "warning"
+)
+
+// An Error is a validation error of the field with the given JSON path.
+type Error struct {
+	Field string `json:"field"`
+	Err   error  `json:"-"`
+	// Severity is empty for errors.
+	Severity Severity `json:"severity,omitempty"`
+}
+
+func (e *Error) Error() string {
+	msg := e.Err.Error()
+	if ruleErr, ok := e.Err.(*RuleError); ok {
+		msg = ruleErr.message(ruleErr.Template, e.Field)
+	}
+	if e.Field == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s: %s", e.Field, msg)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Immutable returns the error reporting a change of the immutable field.
+func Immutable(field string) error {
+	return Invalid(field, ErrImmutable, string(ErrImmutable), "field is immutable", nil)
+}
+
+// Deprecated returns the warning reporting that the deprecated field is set.
+func Deprecated(field string) error {
+	return Warn(Invalid(field, ErrDeprecated, string(ErrDeprecated), "field is deprecated", nil))
+}
+
+// Code returns the code of the first rule failure err contains, or an empty
+// string.
+func Code(err error) string {
+	var ruleErr *RuleError
+	if errors.As(err, &ruleErr) {
+		return ruleErr.Code
+	}
+	return ""
+}
+
+// Warn returns err with the severity of the validation errors it contains
+// lowered to a warning.
+func Warn(err error) error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, e := range joined.Unwrap() {
+			errs = append(errs, Warn(e))
+		}
+		return errors.Join(errs...)
+	}
+	if e, ok := err.(*Error); ok {
+		return &Error{Field: e.Field, Err: e.Err, Severity: SeverityWarning}
+	}
+	return &Error{Err: err, Severity: SeverityWarning}
+}
+
+// IsWarning returns true if err is a validation error with the severity of a
+// warning.
+func IsWarning(err error) bool {
+	e, ok := err.(*Error)
+	return ok && e.Severity == SeverityWarning
+}
+
+// Prefix returns err with the path prepended to the field of the validation
+// errors it contains. Other errors are turned into a validation error of the
+// field with the given path.
+func Prefix(path string, err error) error {
+	if err == nil || path == "" {
+		return err
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, e := range joined.Unwrap() {
+			errs = append(errs, Prefix(path, e))
+		}
+		return errors.Join(errs...)
+	}
+	if e, ok := err.(*Error); ok {
+		return &Error{Field: Join(path, e.Field), Err: e.Err, Severity: e.Severity}
+	}
+	return &Error{Field: path, Err: err}
+}
+
+// Join appends the child path to the parent path.
+func Join(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	case strings.HasPrefix(child, "["):
+		return parent + child
+	}
+	return parent + "." + child
+}
+
+// Index returns the path of the element of a list with the given index.
+func Index(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// SortedKeys returns the keys of the map in ascending order, so the values
+// of a map are validated in a deterministic order.
+func SortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// SortedKeysFunc returns the keys of a map with keys that are not ordered,
+// sorted by their string representation.
+func SortedKeysFunc[M ~map[K]V, K comparable, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b K) int {
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+	return keys
+}
+
+// Key returns the path of the element of a map with the given key.
+func Key(path string, key any) string {
+	return fmt.Sprintf("%s[%v]", path, key)
+}
@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Args are the values of the placeholders of a message template, e.g. min
+// for `{min}`.
+type Args map[string]any
+
+// A Catalog maps error codes to message templates. It allows the generated
+// validators to report their errors in another language: set it as the
+// Catalog of a Result to render the messages of the result with it.
+type Catalog map[string]string
+
+// Message returns the message of err rendered with the template of the
+// catalog for the code of its rule failure, or the message of err if the
+// catalog has no template for it. The message of a validation error does
+// not include the path of its field, which `{field}` refers to.
+func (c Catalog) Message(err error) string {
+	var field string
+	var e *Error
+	if errors.As(err, &e) {
+		field, err = e.Field, e.Err
+	}
+	var ruleErr *RuleError
+	if errors.As(err, &ruleErr) && ruleErr.Code != "" {
+		if template, ok := c[ruleErr.Code]; ok {
+			return ruleErr.message(template, field)
+		}
+	}
+	if ruleErr, ok := err.(*RuleError); ok {
+		return ruleErr.message(ruleErr.Template, field)
+	}
+	return err.Error()
+}
+
+// A RuleError is the failure of a validation rule. Its message is rendered
+// from the template when the error is reported, so a catalog can render it
+// in another language.
+type RuleError struct {
+	// Kind classifies the failure, e.g. ErrLength.
+	Kind Kind
+	// Code identifies the failure, e.g. length.min, and looks up the template
+	// in the catalog.
+	Code     string
+	Template string
+	Args     Args
+}
+
+func (e *RuleError) Error() string {
+	return Format(e.Template, e.Args)
+}
+
+// message renders the template with the args of the failure and `{field}`
+// set to the path of the field that failed the rule.
+func (e *RuleError) message(template, field string) string {
+	if field == "" {
+		return Format(template, e.Args)
+	}
+	args := Args{"field": field}
+	for k, v := range e.Args {
+		args[k] = v
+	}
+	return Format(template, args)
+}
+
+// Is returns true if target is the kind or the code of the failure.
+func (e *RuleError) Is(target error) bool {
+	k, ok := target.(Kind)
+	return ok && (k == e.Kind || string(k) == e.Code)
+}
+
+// Invalid returns the error reporting that the value of the field fails a
+// rule of the given kind. The template may refer to the field with
+// `{field}`, which is rendered with the path of the error when it is
+// reported, i.e. after Prefix added the path of the enclosing values.
+func Invalid(field string, kind Kind, code, template string, args Args) error {
+	return &Error{Field: field, Err: &RuleError{Kind: kind, Code: code, Template: template, Args: args}}
+}
+
+// Format returns the template with the placeholders, e.g. `{min}`, replaced
+// by the values of args. Placeholders without value are kept and `{{` is
+// reported as `{`.
+func Format(template string, args Args) string {
+	var sb strings.Builder
+	for {
+		i := strings.IndexByte(template, '{')
+		if i < 0 {
+			sb.WriteString(template)
+			return sb.String()
+		}
+		sb.WriteString(template[:i])
+		template = template[i:]
+		if strings.HasPrefix(template, "{{") {
+			sb.WriteByte('{')
+			template = template[2:]
+			continue
+		}
+		end := strings.IndexByte(template, '}')
+		if end < 0 {
+			sb.WriteString(template)
+			return sb.String()
+		}
+		if v, ok := args[template[1:end]]; ok {
+			sb.WriteString(fmt.Sprint(v))
+		} else {
+			sb.WriteString(template[:end+1])
+		}
+		template = template[end+1:]
+	}
+}
@@ -0,0 +1,46 @@
+package validation
+
+import "strings"
+
+// TrimSpace returns s without leading and trailing white space.
+func TrimSpace[S ~string](s S) S {
+	return S(strings.TrimSpace(string(s)))
+}
+
+// ToLower returns s with all letters mapped to lower case.
+func ToLower[S ~string](s S) S {
+	return S(strings.ToLower(string(s)))
+}
+
+// Dedupe returns the list without the elements equal to a previous element.
+// The order of the remaining elements is kept. The list is filtered in place
+// and the elements past the result are cleared, so they are not retained.
+func Dedupe[E comparable](s []E) []E {
+	seen := make(map[E]bool, len(s))
+	out := s[:0]
+	for _, e := range s {
+		if !seen[e] {
+			seen[e] = true
+			out = append(out, e)
+		}
+	}
+	clear(s[len(out):])
+	return out
+}
+
+// Default sets the value p points to to v if it is the zero value.
+func Default[T comparable](p *T, v T) {
+	var zero T
+	if *p == zero {
+		*p = v
+	}
+}
+
+// DefaultPointer sets the optional value p points to to v if it is not set
+// or set to the zero value.
+func DefaultPointer[T comparable](p **T, v T) {
+	var zero T
+	if *p == nil || **p == zero {
+		*p = &v
+	}
+}
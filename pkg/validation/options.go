@@ -0,0 +1,116 @@
+package validation
+
+import "slices"
+
+// The validation groups of the generated validation methods. Rules tagged
+// with groups only run when one of their groups is requested, rules without
+// groups always run.
+const (
+	// GroupCreate selects the rules validating a new object. Validate runs
+	// the rules of this group.
+	GroupCreate = "create"
+	// GroupUpdate selects the rules validating an update. ValidateUpdate and
+	// ValidateRatcheting run the rules of this group.
+	GroupUpdate = "update"
+	// GroupStatus selects the status subresource. Resources with a status
+	// subresource only validate their status for this group.
+	GroupStatus = "status"
+)
+
+// Options select the rules run by the generated ValidateWith and
+// ValidateContext methods and when the validation stops.
+type Options struct {
+	// Groups are the requested validation groups.
+	Groups []string
+	// Warnings reports the failures of rules with the severity of a warning
+	// and the use of deprecated fields. Use NewResult to separate them from
+	// the errors.
+	Warnings bool
+	// FailFast stops the validation at the first error, e.g. when only a
+	// yes/no answer is needed.
+	FailFast bool
+	// MaxErrors stops the validation when the given number of errors is
+	// reached. Zero reports all errors.
+	MaxErrors int
+	// MaxDepth limits the nesting of the values of recursive types. Zero
+	// uses DefaultMaxDepth.
+	MaxDepth int
+
+	// ancestors are the values of recursive types being validated, from the
+	// root to the current value.
+	ancestors []any
+}
+
+// DefaultMaxDepth is the default nesting limit of the values of recursive
+// types.
+const DefaultMaxDepth = 1000
+
+// Has returns true if one of the groups is requested.
+func (o Options) Has(groups ...string) bool {
+	for _, group := range groups {
+		if slices.Contains(o.Groups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// Exceeded returns true if errs reached the limit of errors of the options,
+// so the validation stops. Warnings do not count.
+func (o Options) Exceeded(errs error) bool {
+	limit := o.limit()
+	return limit > 0 && countErrors(errs) >= limit
+}
+
+// Nested returns the options of the validation of a nested value after errs
+// were reported, with the limit of errors reduced accordingly.
+func (o Options) Nested(errs error) Options {
+	if limit := o.limit(); limit > 0 {
+		o.FailFast = false
+		o.MaxErrors = max(limit-countErrors(errs), 1)
+	}
+	return o
+}
+
+// Enter returns the options of the validation of the value v of a recursive
+// type, which is called by the generated methods of recursive types with
+// their receiver. It reports a cycle when v is already being validated, and
+// the nesting of values exceeding MaxDepth.
+func (o Options) Enter(v any) (Options, error) {
+	maxDepth := o.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+	if len(o.ancestors) >= maxDepth {
+		return o, Invalid("", ErrDepth, string(ErrDepth), "maximum depth of {max} exceeded", Args{"max": maxDepth})
+	}
+	if slices.Contains(o.ancestors, v) {
+		return o, Invalid("", ErrCycle, string(ErrCycle), "cycle detected", nil)
+	}
+	// the ancestors are copied so sibling values do not share them
+	o.ancestors = append(slices.Clip(o.ancestors), v)
+	return o, nil
+}
+
+// limit returns the maximum number of errors, or 0 if unlimited.
+func (o Options) limit() int {
+	if o.FailFast {
+		return 1
+	}
+	return o.MaxErrors
+}
+
+// countErrors returns the number of errors errs contains, without warnings.
+func countErrors(err error) int {
+	if err == nil || IsWarning(err) {
+		return 0
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		n := 0
+		for _, e := range joined.Unwrap() {
+			n += countErrors(e)
+		}
+		return n
+	}
+	return 1
+}
@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// IsSet returns true if the field v is set. Pointers, slices, maps and
+// strings are set if they are not nil or empty, other values if they are not
+// the zero value of their type.
+func IsSet(v any) bool {
+	if v == nil {
+		return false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		return rv.Len() > 0
+	}
+	return !rv.IsZero()
+}
+
+// Equals returns true if v, or the value v points to, is formatted as value.
+// It compares fields with the values of the conditions of markers.
+func Equals(v any, value string) bool {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return false
+		}
+		rv = rv.Elem()
+	}
+	return rv.IsValid() && fmt.Sprint(rv.Interface()) == value
+}
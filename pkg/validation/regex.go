@@ -0,0 +1,19 @@
+package validation
+
+import (
+	"regexp"
+	"sync"
+)
+
+// patterns caches the compiled patterns of the regex rules.
+var patterns sync.Map
+
+// MatchString returns true if s matches the regular expression pattern. The
+// pattern is compiled once; the generator rejects invalid patterns.
+func MatchString(pattern, s string) bool {
+	re, ok := patterns.Load(pattern)
+	if !ok {
+		re, _ = patterns.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	}
+	return re.(*regexp.Regexp).MatchString(s)
+}
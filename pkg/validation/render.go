@@ -0,0 +1,171 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+)
+
+// A Cause is a single failure of a validation result.
+type Cause struct {
+	// Field is the JSON path of the failed field, empty for the object.
+	Field    string   `json:"field,omitempty"`
+	Code     string   `json:"code,omitempty"`
+	Message  string   `json:"message"`
+	Severity Severity `json:"severity"`
+}
+
+// Causes returns the failures of the result, the errors before the warnings.
+func (r Result) Causes() []Cause {
+	causes := make([]Cause, 0, len(r.Errors)+len(r.Warnings))
+	for _, err := range r.Errors {
+		causes = append(causes, r.newCause(err, SeverityError))
+	}
+	for _, err := range r.Warnings {
+		causes = append(causes, r.newCause(err, SeverityWarning))
+	}
+	return causes
+}
+
+func (r Result) newCause(err error, severity Severity) Cause {
+	cause := Cause{Code: Code(err), Message: r.Catalog.Message(err), Severity: severity}
+	var e *Error
+	if errors.As(err, &e) {
+		cause.Field = e.Field
+	}
+	return cause
+}
+
+// Status is the Kubernetes metav1.Status reporting an invalid object.
+type Status struct {
+	Kind       string         `json:"kind"`
+	APIVersion string         `json:"apiVersion"`
+	Metadata   struct{}       `json:"metadata"`
+	Status     string         `json:"status"`
+	Message    string         `json:"message,omitempty"`
+	Reason     string         `json:"reason,omitempty"`
+	Details    *StatusDetails `json:"details,omitempty"`
+	Code       int            `json:"code"`
+}
+
+// StatusDetails are the details of a Kubernetes Status.
+type StatusDetails struct {
+	Name   string        `json:"name,omitempty"`
+	Group  string        `json:"group,omitempty"`
+	Kind   string        `json:"kind,omitempty"`
+	Causes []StatusCause `json:"causes,omitempty"`
+}
+
+// StatusCause is a cause of a Kubernetes Status.
+type StatusCause struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+}
+
+// Status returns the Kubernetes Status rejecting the object of the given
+// group, kind and name with the errors of the result, like the API server
+// reports invalid objects. Warnings are not part of a Status; Kubernetes
+// returns them in Warning headers. A result without errors returns a Success
+// status.
+func (r Result) Status(group, kind, name string) Status {
+	if len(r.Errors) == 0 {
+		return Status{Kind: "Status", APIVersion: "v1", Status: "Success", Code: http.StatusOK}
+	}
+	status := Status{
+		Kind:       "Status",
+		APIVersion: "v1",
+		Status:     "Failure",
+		Reason:     "Invalid",
+		Code:       http.StatusUnprocessableEntity,
+		Details:    &StatusDetails{Name: name, Group: group, Kind: kind},
+	}
+	var messages []string
+	for _, err := range r.Errors {
+		cause := r.newCause(err, SeverityError)
+		status.Details.Causes = append(status.Details.Causes, StatusCause{
+			Reason:  statusReason(err),
+			Message: cause.Message,
+			Field:   cause.Field,
+		})
+		messages = append(messages, cause.String())
+	}
+	qualifiedKind := kind
+	if group != "" {
+		qualifiedKind = kind + "." + group
+	}
+	status.Message = fmt.Sprintf("%s %q is invalid: [%s]", qualifiedKind, name, strings.Join(messages, ", "))
+	return status
+}
+
+// statusReason returns the reason of the StatusCause of err, like the
+// API server reports field errors.
+func statusReason(err error) string {
+	switch {
+	case errors.Is(err, ErrEnum):
+		return "FieldValueNotSupported"
+	case errors.Is(err, ErrRequired):
+		return "FieldValueRequired"
+	case errors.Is(err, ErrUnique):
+		return "FieldValueDuplicate"
+	case errors.Is(err, ErrImmutable), errors.Is(err, ErrExcluded):
+		return "FieldValueForbidden"
+	}
+	return "FieldValueInvalid"
+}
+
+func (c Cause) String() string {
+	if c.Field == "" {
+		return c.Message
+	}
+	return fmt.Sprintf("%s: %s", c.Field, c.Message)
+}
+
+// ProblemContentType is the media type of a Problem.
+const ProblemContentType = "application/problem+json"
+
+// Problem is the RFC 7807 problem details reporting an invalid object, with
+// the failures in the errors extension member.
+type Problem struct {
+	Type     string  `json:"type"`
+	Title    string  `json:"title"`
+	Status   int     `json:"status"`
+	Detail   string  `json:"detail,omitempty"`
+	Instance string  `json:"instance,omitempty"`
+	Errors   []Cause `json:"errors"`
+}
+
+// Problem returns the problem details of the result for the request URI
+// instance, which may be empty. The warnings are reported in the errors
+// member with their severity.
+func (r Result) Problem(instance string) Problem {
+	return Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(http.StatusUnprocessableEntity),
+		Status:   http.StatusUnprocessableEntity,
+		Detail:   fmt.Sprintf("validation failed with %d error(s) and %d warning(s)", len(r.Errors), len(r.Warnings)),
+		Instance: instance,
+		Errors:   r.Causes(),
+	}
+}
+
+// WriteText writes the failures of the result as a table aligned for
+// humans, e.g. for a CLI.
+func (r Result) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SEVERITY\tFIELD\tCODE\tMESSAGE")
+	for _, cause := range r.Causes() {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", cause.Severity, orDash(cause.Field), orDash(cause.Code), cause.Message)
+	}
+	return tw.Flush()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,48 @@
+package validation
+
+import "errors"
+
+// A Result is the outcome of a validation with the warnings separated from
+// the errors, so the warnings can be reported without rejecting the object.
+type Result struct {
+	Errors   []error
+	Warnings []error
+	// Catalog, if set, renders the messages of the causes, the Status, the
+	// Problem and the text of the result.
+	Catalog Catalog
+}
+
+// NewResult separates the warnings from the errors of the error returned by
+// a generated validation method.
+func NewResult(err error) Result {
+	var r Result
+	r.add(err)
+	return r
+}
+
+func (r *Result) add(err error) {
+	if err == nil {
+		return
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			r.add(e)
+		}
+		return
+	}
+	if IsWarning(err) {
+		r.Warnings = append(r.Warnings, err)
+		return
+	}
+	r.Errors = append(r.Errors, err)
+}
+
+// Err returns the errors of the result joined, or nil if there are none.
+func (r Result) Err() error {
+	return errors.Join(r.Errors...)
+}
+
+// OK returns true if the result has no errors.
+func (r Result) OK() bool {
+	return len(r.Errors) == 0
+}
@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+)
+
+// A Union is a set of optional fields of which at most one, or exactly one,
+// is set. The discriminator field, if any, holds the JSON name of the member
+// that is set.
+type Union struct {
+	// Members are the JSON names of the member fields.
+	Members []string
+	// ExactlyOne requires a member to be set.
+	ExactlyOne bool
+	// Discriminator is the JSON name of the discriminator field, if any.
+	Discriminator string
+}
+
+// Check returns the errors of the union given which of its members are set,
+// in the order of the members, and the value of the discriminator.
+func (u Union) Check(set []bool, discriminator string) error {
+	var errs []error
+	members := strings.Join(u.Members, ", ")
+	first := -1
+	for i, ok := range set {
+		switch {
+		case !ok:
+		case first < 0:
+			first = i
+		default:
+			errs = append(errs, Invalid(u.Members[i], ErrUnion, "union.at_most_one", "only one of {members} may be set, got {other}",
+				Args{"members": members, "other": u.Members[first]}))
+		}
+	}
+	if first < 0 && u.ExactlyOne {
+		errs = append(errs, Invalid("", ErrUnion, "union.exactly_one", "one of {members} must be set", Args{"members": members}))
+	}
+	if u.Discriminator == "" {
+		return errors.Join(errs...)
+	}
+
+	named := u.named(discriminator)
+	switch {
+	case discriminator != "" && named < 0:
+		errs = append(errs, Invalid(u.Discriminator, ErrUnion, "union.discriminator", "must be one of {members}, got {value}",
+			Args{"members": members, "value": discriminator}))
+	case first >= 0 && named != first:
+		errs = append(errs, Invalid(u.Discriminator, ErrUnion, "union.discriminator", "must be {member} when {member} is set, got {value}",
+			Args{"member": u.Members[first], "value": discriminator}))
+	case first < 0 && named >= 0:
+		errs = append(errs, Invalid(u.Members[named], ErrUnion, "union.discriminator", "must be set when {discriminator} is {value}",
+			Args{"discriminator": u.Discriminator, "value": discriminator}))
+	}
+	return errors.Join(errs...)
+}
+
+// named returns the index of the member named by the discriminator, or -1.
+func (u Union) named(discriminator string) int {
+	for i, member := range u.Members {
+		if member == discriminator {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,176 @@
+package validation
+
+import (
+	"reflect"
+	"time"
+)
+
+// ElemAt returns a pointer to the element of the slice with the given index,
+// or nil if the slice has no such element. It looks up the old value of a
+// list element during the validation of an update.
+func ElemAt[E any](s []E, i int) *E {
+	if i < 0 || i >= len(s) {
+		return nil
+	}
+	return &s[i]
+}
+
+// PointerAt returns the element of the slice of pointers with the given
+// index, or nil if the slice has no such element.
+func PointerAt[E any](s []*E, i int) *E {
+	if i < 0 || i >= len(s) {
+		return nil
+	}
+	return s[i]
+}
+
+// IsZero returns true if v is the zero value of its type, e.g. an unset
+// optional field.
+func IsZero(v any) bool {
+	if v == nil {
+		return true
+	}
+	return reflect.ValueOf(v).IsZero()
+}
+
+// DeepEqual returns true if a and b are deeply equal like reflect.DeepEqual,
+// except that time.Time values are equal if they denote the same instant,
+// whatever their location and monotonic clock reading. It detects the changes
+// of fields on update, so a time that was encoded and decoded again is not
+// reported as changed.
+func DeepEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	return va.Type() == vb.Type() && deepEqual(va, vb, map[visit]bool{})
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// A visit is a comparison of references in progress, which is assumed to be
+// true when it is seen again so cyclic values terminate.
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+func deepEqual(a, b reflect.Value, visited map[visit]bool) bool {
+	if a.Type() == timeType && a.CanInterface() {
+		return a.Interface().(time.Time).Equal(b.Interface().(time.Time))
+	}
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		v := visit{a: a.Pointer(), b: b.Pointer(), typ: a.Type()}
+		if a.Kind() != reflect.Slice && v.a == v.b || visited[v] {
+			return true
+		}
+		visited[v] = true
+	}
+	switch a.Kind() {
+	case reflect.Pointer:
+		return deepEqual(a.Elem(), b.Elem(), visited)
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return a.Elem().Type() == b.Elem().Type() && deepEqual(a.Elem(), b.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !deepEqual(a.Field(i), b.Field(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Array, reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !deepEqual(a.Index(i), b.Index(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			bv := b.MapIndex(iter.Key())
+			if !bv.IsValid() || !deepEqual(iter.Value(), bv, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Func:
+		return a.IsNil() && b.IsNil()
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	}
+	return false
+}
+
+// MapElemAt returns a pointer to a copy of the element of the map with the
+// given key, or nil if the map has no such element.
+func MapElemAt[K comparable, V any](m map[K]V, k K) *V {
+	v, ok := m[k]
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// MapPointerAt returns the element of the map of pointers with the given
+// key, or nil if the map has no such element.
+func MapPointerAt[K comparable, V any](m map[K]*V, k K) *V {
+	return m[k]
+}
+
+// IndexOf returns the first index i in [0, n) for which match returns true,
+// or -1. It correlates the elements of a list of type map with the elements
+// of the old list during ratcheting validation.
+func IndexOf(n int, match func(i int) bool) int {
+	for i := 0; i < n; i++ {
+		if match(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Deref returns the value p points to, or the zero value if p is nil. It
+// looks up the old value of a nested list or map that may not exist.
+func Deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// UniqueKey returns the value p points to, or nil if p is nil. It compares
+// the pointer key fields of the elements of a list by value in the unique
+// checks.
+func UniqueKey[T comparable](p *T) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}